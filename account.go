@@ -0,0 +1,214 @@
+package twitter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// VerifyCredentialsParams represents parameters for
+// /account/verify_credentials.json Twitter endpoint. IncludeEmail requires the
+// application to be whitelisted for email access.
+type VerifyCredentialsParams struct {
+	IncludeEmail    bool
+	ExcludeEntities bool
+	SkipStatus      bool
+}
+
+// VerifyCredentials calls Twitter endpoint /account/verify_credentials.json
+// and returns the user the access credentials belong to. Use
+// WithAccessCredentials to verify credentials other than the Client's. If the
+// token has been revoked, IsInvalidToken reports true for the returned error.
+func (c *Client) VerifyCredentials(ctx context.Context, params VerifyCredentialsParams) (*UserResponse, error) {
+	values := url.Values{}
+	if params.IncludeEmail {
+		values.Set("include_email", "true")
+	}
+	setProfileFlags(values, params.ExcludeEntities, params.SkipStatus)
+	urlStr := c.apiURL("/1.1/account/verify_credentials.json")
+	return c.handleUserResponse(ctx, "GET", urlStr, values)
+}
+
+// AccountSettings calls Twitter endpoint /account/settings.json
+func (c *Client) AccountSettings(ctx context.Context) (*SettingsResponse, error) {
+	return c.handleSettingsResponse(ctx, "GET", url.Values{})
+}
+
+// UpdateAccountSettingsParams represents parameters for a POST to the
+// /account/settings.json Twitter endpoint. Only the non-empty fields are
+// updated. Sleep times are hours from 0 to 23.
+type UpdateAccountSettingsParams struct {
+	SleepTimeEnabled   *bool
+	StartSleepTime     *int
+	EndSleepTime       *int
+	TimeZone           string
+	TrendLocationWOEID int64
+	Lang               string
+}
+
+// UpdateAccountSettings calls Twitter endpoint /account/settings.json with a
+// POST request.
+func (c *Client) UpdateAccountSettings(ctx context.Context, params UpdateAccountSettingsParams) (*SettingsResponse, error) {
+	values := updateAccountSettingsToQuery(params)
+	return c.handleSettingsResponse(ctx, "POST", values)
+}
+
+func updateAccountSettingsToQuery(params UpdateAccountSettingsParams) url.Values {
+	values := url.Values{}
+	if params.SleepTimeEnabled != nil {
+		values.Set("sleep_time_enabled", strconv.FormatBool(*params.SleepTimeEnabled))
+	}
+	if params.StartSleepTime != nil {
+		values.Set("start_sleep_time", strconv.Itoa(*params.StartSleepTime))
+	}
+	if params.EndSleepTime != nil {
+		values.Set("end_sleep_time", strconv.Itoa(*params.EndSleepTime))
+	}
+	if params.TimeZone != "" {
+		values.Set("time_zone", params.TimeZone)
+	}
+	if params.TrendLocationWOEID != 0 {
+		values.Set("trend_location_woeid", strconv.FormatInt(params.TrendLocationWOEID, 10))
+	}
+	if params.Lang != "" {
+		values.Set("lang", params.Lang)
+	}
+	return values
+}
+
+func (c *Client) handleSettingsResponse(ctx context.Context, method string, values url.Values) (*SettingsResponse, error) {
+	resp, err := c.do(ctx, method, c.apiURL("/1.1/account/settings.json"), values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var settings Settings
+	err = json.NewDecoder(resp.Body).Decode(&settings)
+	if err != nil {
+		return nil, err
+	}
+	return &SettingsResponse{
+		Settings:  settings,
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
+
+// UpdateProfileParams represents parameters for /account/update_profile.json
+// Twitter endpoint. Only the non-empty fields are updated.
+type UpdateProfileParams struct {
+	Name             string
+	URL              string
+	Location         string
+	Description      string
+	ProfileLinkColor string
+	ExcludeEntities  bool
+	SkipStatus       bool
+}
+
+// UpdateProfile calls Twitter endpoint /account/update_profile.json
+func (c *Client) UpdateProfile(ctx context.Context, params UpdateProfileParams) (*UserResponse, error) {
+	values := updateProfileToQuery(params)
+	urlStr := c.apiURL("/1.1/account/update_profile.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
+
+func updateProfileToQuery(params UpdateProfileParams) url.Values {
+	values := url.Values{}
+	if params.Name != "" {
+		values.Set("name", params.Name)
+	}
+	if params.URL != "" {
+		values.Set("url", params.URL)
+	}
+	if params.Location != "" {
+		values.Set("location", params.Location)
+	}
+	if params.Description != "" {
+		values.Set("description", params.Description)
+	}
+	if params.ProfileLinkColor != "" {
+		values.Set("profile_link_color", params.ProfileLinkColor)
+	}
+	setProfileFlags(values, params.ExcludeEntities, params.SkipStatus)
+	return values
+}
+
+// UpdateProfileImageParams represents parameters for
+// /account/update_profile_image.json Twitter endpoint. Image is the raw GIF,
+// JPG or PNG data of at most 700KB.
+type UpdateProfileImageParams struct {
+	Image           []byte
+	ExcludeEntities bool
+	SkipStatus      bool
+}
+
+// UpdateProfileImage calls Twitter endpoint /account/update_profile_image.json
+func (c *Client) UpdateProfileImage(ctx context.Context, params UpdateProfileImageParams) (*UserResponse, error) {
+	values := url.Values{}
+	values.Set("image", base64.StdEncoding.EncodeToString(params.Image))
+	setProfileFlags(values, params.ExcludeEntities, params.SkipStatus)
+	urlStr := c.apiURL("/1.1/account/update_profile_image.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
+
+func setProfileFlags(values url.Values, excludeEntities, skipStatus bool) {
+	if excludeEntities {
+		values.Set("include_entities", "false")
+	}
+	if skipStatus {
+		values.Set("skip_status", "true")
+	}
+}
+
+// UpdateProfileBannerParams represents parameters for
+// /account/update_profile_banner.json Twitter endpoint. Banner is the raw
+// image data of at most 5MB. The optional Width, Height, OffsetLeft and
+// OffsetTop crop the image and are only sent when Width is set.
+type UpdateProfileBannerParams struct {
+	Banner     []byte
+	Width      int
+	Height     int
+	OffsetLeft int
+	OffsetTop  int
+}
+
+// UpdateProfileBanner calls Twitter endpoint
+// /account/update_profile_banner.json
+func (c *Client) UpdateProfileBanner(ctx context.Context, params UpdateProfileBannerParams) (*EmptyResponse, error) {
+	values := url.Values{}
+	values.Set("banner", base64.StdEncoding.EncodeToString(params.Banner))
+	if params.Width > 0 {
+		values.Set("width", strconv.Itoa(params.Width))
+		values.Set("height", strconv.Itoa(params.Height))
+		values.Set("offset_left", strconv.Itoa(params.OffsetLeft))
+		values.Set("offset_top", strconv.Itoa(params.OffsetTop))
+	}
+	urlStr := c.apiURL("/1.1/account/update_profile_banner.json")
+	return c.handleEmptyResponse(ctx, "POST", urlStr, values)
+}
+
+// RemoveProfileBanner calls Twitter endpoint
+// /account/remove_profile_banner.json
+func (c *Client) RemoveProfileBanner(ctx context.Context) (*EmptyResponse, error) {
+	urlStr := c.apiURL("/1.1/account/remove_profile_banner.json")
+	return c.handleEmptyResponse(ctx, "POST", urlStr, url.Values{})
+}
+
+func (c *Client) handleEmptyResponse(ctx context.Context, method, urlStr string, values url.Values) (*EmptyResponse, error) {
+	resp, err := c.do(ctx, method, urlStr, values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
@@ -0,0 +1,233 @@
+package twitter
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Account", func() {
+	Context("VerifyCredentials", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("oops")
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.VerifyCredentials(context.Background(), VerifyCredentialsParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+			Ω(IsInvalidToken(err)).Should(BeFalse())
+		})
+
+		It("should return the user for the context's credentials", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("GET"))
+					Ω(req.URL.Path).Should(Equal("/1.1/account/verify_credentials.json"))
+					Ω(req.FormValue("include_email")).Should(Equal("true"))
+					Ω(req.FormValue("skip_status")).Should(Equal("true"))
+					Ω(req.Header.Get("Authorization")).Should(ContainSubstring(`oauth_token="customer-token"`))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1","screen_name":"crowdriff","email":"hello@crowdriff.com"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			ctx := WithAccessCredentials(context.Background(), AccessCredentials{Token: "customer-token", Secret: "secret"})
+			res, err := client.VerifyCredentials(ctx, VerifyCredentialsParams{IncludeEmail: true, SkipStatus: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.User.ScreenName).Should(Equal("crowdriff"))
+			Ω(res.User.Email).Should(Equal("hello@crowdriff.com"))
+		})
+
+		It("should report revoked tokens", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 401,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 89, "message": "Invalid or expired token."}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.VerifyCredentials(context.Background(), VerifyCredentialsParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(IsInvalidToken(err)).Should(BeTrue())
+		})
+
+		It("should not report other errors as revoked tokens", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 429,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 88, "message": "Rate limit exceeded"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.VerifyCredentials(context.Background(), VerifyCredentialsParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(IsInvalidToken(err)).Should(BeFalse())
+		})
+	})
+
+	Context("AccountSettings", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("oops")
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.AccountSettings(context.Background())
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return the settings", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("GET"))
+					Ω(req.URL.Path).Should(Equal("/1.1/account/settings.json"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"screen_name":"crowdriff","language":"en","sleep_time":{"enabled":true,"start_time":23,"end_time":7},"time_zone":{"name":"Eastern Time (US & Canada)","tzinfo_name":"America/New_York","utc_offset":-18000},"trend_location":[{"name":"Toronto","woeid":4118}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.AccountSettings(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Settings.ScreenName).Should(Equal("crowdriff"))
+			Ω(*res.Settings.SleepTime.StartTime).Should(Equal(23))
+			Ω(res.Settings.TimeZone.TZInfoName).Should(Equal("America/New_York"))
+			Ω(res.Settings.TrendLocation[0].WOEID).Should(Equal(int64(4118)))
+		})
+	})
+
+	Context("UpdateAccountSettings", func() {
+		It("should update only the provided settings", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					req.ParseForm()
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/account/settings.json"))
+					Ω(req.PostForm).Should(Equal(url.Values{
+						"sleep_time_enabled":   {"false"},
+						"trend_location_woeid": {"4118"},
+					}))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			enabled := false
+			_, err := client.UpdateAccountSettings(context.Background(), UpdateAccountSettingsParams{
+				SleepTimeEnabled:   &enabled,
+				TrendLocationWOEID: 4118,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("UpdateProfile", func() {
+		It("should update only the provided fields", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/account/update_profile.json"))
+					Ω(req.FormValue("description")).Should(Equal("Visual content"))
+					Ω(req.FormValue("include_entities")).Should(Equal("false"))
+					Ω(req.PostForm).ShouldNot(HaveKey("name"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.UpdateProfile(context.Background(), UpdateProfileParams{Description: "Visual content", ExcludeEntities: true})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("UpdateProfileImage", func() {
+		It("should base64 encode the image", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/account/update_profile_image.json"))
+					Ω(req.FormValue("image")).Should(Equal(base64.StdEncoding.EncodeToString([]byte("png"))))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.UpdateProfileImage(context.Background(), UpdateProfileImageParams{Image: []byte("png")})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("UpdateProfileBanner", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("oops")
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.UpdateProfileBanner(context.Background(), UpdateProfileBannerParams{Banner: []byte("jpg")})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should base64 encode the banner and send the crop", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/account/update_profile_banner.json"))
+					Ω(req.FormValue("banner")).Should(Equal(base64.StdEncoding.EncodeToString([]byte("jpg"))))
+					Ω(req.FormValue("width")).Should(Equal("1500"))
+					Ω(req.FormValue("offset_top")).Should(Equal("0"))
+					return &http.Response{
+						StatusCode: 201,
+						Body:       ioutil.NopCloser(strings.NewReader(``)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.UpdateProfileBanner(context.Background(), UpdateProfileBannerParams{Banner: []byte("jpg"), Width: 1500, Height: 500})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("RemoveProfileBanner", func() {
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/account/remove_profile_banner.json"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(``)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.RemoveProfileBanner(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+})
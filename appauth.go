@@ -0,0 +1,129 @@
+package twitter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// bearerToken caches the application-only bearer token. It is shared between
+// shallow copies of a Client so the token is only requested once.
+type bearerToken struct {
+	mu    sync.Mutex
+	token string
+}
+
+// errAppOnlyDisabled is returned when bearer tokens are managed on a Client
+// that does not use application-only auth.
+var errAppOnlyDisabled = errors.New("app-only auth is not enabled")
+
+// BearerTokenResponse represents a response from Twitter containing an
+// application-only bearer token.
+type BearerTokenResponse struct {
+	TokenType   string `json:"token_type"`
+	AccessToken string `json:"access_token"`
+}
+
+// WithAppOnlyAuth returns a new shallow copy of the Client that authenticates
+// requests with an application-only (OAuth 2) bearer token instead of OAuth
+// 1.0a user context. The bearer token is requested on first use and cached.
+func (c *Client) WithAppOnlyAuth() *Client {
+	newC := *c
+	newC.appOnly = true
+	if newC.bearer == nil {
+		newC.bearer = &bearerToken{}
+	}
+	return &newC
+}
+
+// BearerToken returns the cached application-only bearer token, requesting a
+// new one from the Twitter /oauth2/token endpoint if none is cached. It returns
+// an error unless the Client was returned by WithAppOnlyAuth.
+func (c *Client) BearerToken(ctx context.Context) (string, error) {
+	if !c.appOnly {
+		return "", errAppOnlyDisabled
+	}
+	c.bearer.mu.Lock()
+	defer c.bearer.mu.Unlock()
+	if c.bearer.token != "" {
+		return c.bearer.token, nil
+	}
+
+	values := url.Values{}
+	values.Set("grant_type", "client_credentials")
+	resp, err := c.doBasic(ctx, c.apiURL("/oauth2/token"), values)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return "", err
+	}
+	var res BearerTokenResponse
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return "", err
+	}
+	if res.TokenType != "bearer" || res.AccessToken == "" {
+		return "", errors.New("invalid bearer token response")
+	}
+	c.bearer.token = res.AccessToken
+	return c.bearer.token, nil
+}
+
+// InvalidateBearerToken calls the Twitter /oauth2/invalidate_token endpoint to
+// revoke the cached application-only bearer token and clears it from the
+// cache. It returns an error unless the Client was returned by WithAppOnlyAuth.
+func (c *Client) InvalidateBearerToken(ctx context.Context) error {
+	if !c.appOnly {
+		return errAppOnlyDisabled
+	}
+	c.bearer.mu.Lock()
+	defer c.bearer.mu.Unlock()
+	if c.bearer.token == "" {
+		return nil
+	}
+
+	values := url.Values{}
+	values.Set("access_token", c.bearer.token)
+	resp, err := c.doBasic(ctx, c.apiURL("/oauth2/invalidate_token"), values)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return err
+	}
+	c.bearer.token = ""
+	return nil
+}
+
+// clearBearerToken drops the cached bearer token so that it will be requested
+// again on the next call.
+func (c *Client) clearBearerToken(token string) {
+	c.bearer.mu.Lock()
+	if c.bearer.token == token {
+		c.bearer.token = ""
+	}
+	c.bearer.mu.Unlock()
+}
+
+// doBasic makes a form encoded POST request authenticated with HTTP basic auth
+// using the consumer credentials, as required by the OAuth 2 token endpoints.
+func (c *Client) doBasic(ctx context.Context, urlStr string, values url.Values) (*http.Response, error) {
+	req, err := http.NewRequest("POST", urlStr, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	creds := url.QueryEscape(c.oauthClient.Credentials.Token) + ":" + url.QueryEscape(c.oauthClient.Credentials.Secret)
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(creds)))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=UTF-8")
+	return c.httpClient.Do(req)
+}
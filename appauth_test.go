@@ -0,0 +1,144 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppAuth", func() {
+	Context("WithAppOnlyAuth", func() {
+		It("should request a bearer token once and use it for requests", func() {
+			tokenRequests := 0
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					if req.URL.Path == "/oauth2/token" {
+						tokenRequests++
+						Ω(req.Header.Get("Authorization")).Should(Equal("Basic a2V5OnNlY3JldA=="))
+						Ω(req.FormValue("grant_type")).Should(Equal("client_credentials"))
+						return &http.Response{
+							StatusCode: 200,
+							Body:       ioutil.NopCloser(strings.NewReader(`{"token_type":"bearer","access_token":"AAAA"}`)),
+						}, nil
+					}
+					Ω(req.Header.Get("Authorization")).Should(Equal("Bearer AAAA"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`[]`)),
+					}, nil
+				},
+			}
+
+			client := (&Client{
+				httpClient: &hm,
+				oauthClient: &oauth.Client{
+					Credentials: oauth.Credentials{
+						Token:  "key",
+						Secret: "secret",
+					},
+				},
+				accessCreds: &oauth.Credentials{},
+			}).WithAppOnlyAuth()
+
+			ctx := context.Background()
+			_, err := client.UserTimeline(ctx, UserTimelineParams{ScreenName: "someone"})
+			Ω(err).ShouldNot(HaveOccurred())
+			_, err = client.UserTimeline(ctx, UserTimelineParams{ScreenName: "someone"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(tokenRequests).Should(Equal(1))
+		})
+
+		It("should return error when the token request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 403,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors":[{"code": 99, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+
+			client := (&Client{
+				httpClient:  &hm,
+				oauthClient: &oauth.Client{},
+				accessCreds: &oauth.Credentials{},
+			}).WithAppOnlyAuth()
+
+			_, err := client.UserTimeline(context.Background(), UserTimelineParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+	})
+
+	Context("BearerToken", func() {
+		It("should return error when app-only auth is not enabled", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Fail("unexpected request")
+					return nil, nil
+				},
+			}
+
+			client := Client{
+				httpClient:  &hm,
+				oauthClient: &oauth.Client{},
+				accessCreds: &oauth.Credentials{},
+				bearer:      &bearerToken{},
+			}
+
+			_, err := client.BearerToken(context.Background())
+			Ω(err).Should(MatchError("app-only auth is not enabled"))
+		})
+	})
+
+	Context("InvalidateBearerToken", func() {
+		It("should invalidate and clear the cached token", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/oauth2/invalidate_token"))
+					Ω(req.FormValue("access_token")).Should(Equal("AAAA"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"access_token":"AAAA"}`)),
+					}, nil
+				},
+			}
+
+			client := (&Client{
+				httpClient:  &hm,
+				oauthClient: &oauth.Client{},
+				accessCreds: &oauth.Credentials{},
+				bearer:      &bearerToken{token: "AAAA"},
+			}).WithAppOnlyAuth()
+
+			err := client.InvalidateBearerToken(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(client.bearer.token).Should(Equal(""))
+		})
+
+		It("should return error when app-only auth is not enabled", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Fail("unexpected request")
+					return nil, nil
+				},
+			}
+
+			client := Client{
+				httpClient:  &hm,
+				oauthClient: &oauth.Client{},
+				accessCreds: &oauth.Credentials{},
+				bearer:      &bearerToken{token: "AAAA"},
+			}
+
+			err := client.InvalidateBearerToken(context.Background())
+			Ω(err).Should(MatchError("app-only auth is not enabled"))
+			Ω(client.bearer.token).Should(Equal("AAAA"))
+		})
+	})
+})
@@ -9,6 +9,10 @@ import (
 
 // RateLimitsRes ...
 type RateLimitsRes struct {
+	// All holds every returned rate limit, keyed by resource family and then
+	// by resource, including those without a field in Resources.
+	All map[string]map[string]RateLimit `json:"-"`
+
 	RateLimitContext struct {
 		AccessToken string `json:"access_token"`
 	} `json:"rate_limit_context"`
@@ -48,13 +52,30 @@ type RateLimitsRes struct {
 	} `json:"resources"`
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface, filling All in
+// addition to the named resources.
+func (r *RateLimitsRes) UnmarshalJSON(b []byte) error {
+	type rateLimitsRes RateLimitsRes
+	if err := json.Unmarshal(b, (*rateLimitsRes)(r)); err != nil {
+		return err
+	}
+	var all struct {
+		Resources map[string]map[string]RateLimit `json:"resources"`
+	}
+	if err := json.Unmarshal(b, &all); err != nil {
+		return err
+	}
+	r.All = all.Resources
+	return nil
+}
+
 // RateLimitStatus calls the Twitter /application/rate_limit_status.json endpoint.
 func (c *Client) RateLimitStatus(ctx context.Context, resources []string) (*RateLimitStatusResponse, error) {
 	values := url.Values{}
 	if len(resources) > 0 {
 		values.Set("resources", strings.Join(resources, ","))
 	}
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/application/rate_limit_status.json", values)
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/application/rate_limit_status.json"), values)
 	if err != nil {
 		return nil, err
 	}
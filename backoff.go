@@ -1,6 +1,9 @@
 package twitter
 
-import "time"
+import (
+	"math/rand"
+	"time"
+)
 
 const (
 	maxNetDelay      = 16 * time.Second
@@ -25,18 +28,77 @@ type Backoff interface {
 	Retries() int
 }
 
+// BackoffPolicy is the interface for deciding how long a stream waits before
+// reconnecting. A stream calls exactly one of NetworkError, HTTPError or Reset
+// after each connection attempt, followed by Wait before reconnecting.
+type BackoffPolicy interface {
+	Backoff
+	// NetworkError records a TCP/IP level error.
+	NetworkError()
+	// HTTPError records an HTTP error with the provided status code.
+	HTTPError(statusCode int)
+	// Reset records that a stream was successfully established and has
+	// since disconnected.
+	Reset()
+	// Wait records a retry and returns the duration to wait before it.
+	Wait() time.Duration
+}
+
+// BackoffParams represents the delays used by the default stream reconnect
+// policy. Zero fields use the values documented by Twitter.
+// https://dev.twitter.com/streaming/overview/connecting
+type BackoffParams struct {
+	// NetDelayInc is the linear increase in delay after each network error.
+	NetDelayInc time.Duration
+	// MaxNetDelay caps the delay after network errors.
+	MaxNetDelay time.Duration
+	// HTTPInitDelay is the initial delay after an HTTP error, which is
+	// doubled after each subsequent HTTP error.
+	HTTPInitDelay time.Duration
+	// HTTP420InitDelay is the initial delay after a 420 (rate limited) error.
+	HTTP420InitDelay time.Duration
+	// MaxHTTPDelay caps the delay after HTTP errors.
+	MaxHTTPDelay time.Duration
+	// Jitter adds a random delay of up to this fraction of each wait, so that
+	// many streams do not reconnect in lockstep.
+	Jitter float64
+}
+
+// NewBackoff returns a BackoffPolicy implementing the Twitter reconnect policy
+// with the provided delays.
+func NewBackoff(params BackoffParams) BackoffPolicy {
+	if params.NetDelayInc <= 0 {
+		params.NetDelayInc = netDelayInc
+	}
+	if params.MaxNetDelay <= 0 {
+		params.MaxNetDelay = maxNetDelay
+	}
+	if params.HTTPInitDelay <= 0 {
+		params.HTTPInitDelay = httpInitDelay
+	}
+	if params.HTTP420InitDelay <= 0 {
+		params.HTTP420InitDelay = http420InitDelay
+	}
+	if params.MaxHTTPDelay <= 0 {
+		params.MaxHTTPDelay = maxHTTPDelay
+	}
+	return &backoff{params: params}
+}
+
 type backoff struct {
+	params    BackoffParams
 	netDelay  time.Duration
 	httpDelay time.Duration
+	jitter    time.Duration
 	waited    time.Duration
 	retries   int
 }
 
 func (b *backoff) NextWait() time.Duration {
 	if b.netDelay > b.httpDelay {
-		return b.netDelay
+		return b.netDelay + b.jitter
 	}
-	return b.httpDelay
+	return b.httpDelay + b.jitter
 }
 
 func (b *backoff) Waited() time.Duration {
@@ -47,43 +109,55 @@ func (b *backoff) Retries() int {
 	return b.retries
 }
 
-func (b *backoff) reset() {
+func (b *backoff) Reset() {
 	b.netDelay = 0
 	b.httpDelay = 0
+	b.jitter = 0
 	b.waited = 0
 	b.retries = 0
 }
 
-func (b *backoff) wait() time.Duration {
-	var wait time.Duration
-	if b.netDelay > b.httpDelay {
-		wait = b.netDelay
-	} else {
-		wait = b.httpDelay
-	}
+func (b *backoff) Wait() time.Duration {
+	wait := b.NextWait()
 	b.waited += wait
 	b.retries++
 	return wait
 }
 
-func (b *backoff) incNetDelay() {
-	b.netDelay += netDelayInc
-	if b.netDelay > maxNetDelay {
-		b.netDelay = maxNetDelay
+func (b *backoff) NetworkError() {
+	b.netDelay += b.params.NetDelayInc
+	if b.netDelay > b.params.MaxNetDelay {
+		b.netDelay = b.params.MaxNetDelay
 	}
+	b.setJitter()
 }
 
-func (b *backoff) incHTTPDelay(is420 bool) {
+func (b *backoff) HTTPError(statusCode int) {
+	defer b.setJitter()
 	if b.httpDelay <= 0 {
-		if is420 {
-			b.httpDelay = http420InitDelay
+		if statusCode == 420 {
+			b.httpDelay = b.params.HTTP420InitDelay
 			return
 		}
-		b.httpDelay = httpInitDelay
+		b.httpDelay = b.params.HTTPInitDelay
 		return
 	}
 	b.httpDelay *= 2
-	if b.httpDelay > maxHTTPDelay {
-		b.httpDelay = maxHTTPDelay
+	if b.httpDelay > b.params.MaxHTTPDelay {
+		b.httpDelay = b.params.MaxHTTPDelay
+	}
+}
+
+func (b *backoff) setJitter() {
+	b.jitter = 0
+	if b.params.Jitter <= 0 {
+		return
+	}
+	d := b.netDelay
+	if b.httpDelay > d {
+		d = b.httpDelay
+	}
+	if max := int64(float64(d) * b.params.Jitter); max > 0 {
+		b.jitter = time.Duration(rand.Int63n(max + 1))
 	}
 }
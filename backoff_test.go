@@ -0,0 +1,45 @@
+package twitter
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Backoff", func() {
+	Context("NewBackoff", func() {
+		It("should follow the Twitter reconnect policy by default", func() {
+			b := NewBackoff(BackoffParams{})
+			b.NetworkError()
+			b.NetworkError()
+			Ω(b.NextWait()).Should(Equal(500 * time.Millisecond))
+			Ω(b.Wait()).Should(Equal(500 * time.Millisecond))
+			Ω(b.Retries()).Should(Equal(1))
+
+			b.Reset()
+			b.HTTPError(420)
+			Ω(b.NextWait()).Should(Equal(time.Minute))
+			for i := 0; i < 10; i++ {
+				b.HTTPError(503)
+			}
+			Ω(b.NextWait()).Should(Equal(320 * time.Second))
+			Ω(b.Waited()).Should(Equal(time.Duration(0)))
+		})
+
+		It("should use the provided delays and jitter", func() {
+			b := NewBackoff(BackoffParams{
+				HTTPInitDelay: time.Second,
+				MaxHTTPDelay:  3 * time.Second,
+				Jitter:        0.5,
+			})
+			b.HTTPError(503)
+			Ω(b.NextWait()).Should(BeNumerically(">=", time.Second))
+			Ω(b.NextWait()).Should(BeNumerically("<=", 1500*time.Millisecond))
+			b.HTTPError(503)
+			b.HTTPError(503)
+			Ω(b.NextWait()).Should(BeNumerically(">=", 3*time.Second))
+			Ω(b.NextWait()).Should(BeNumerically("<=", 4500*time.Millisecond))
+		})
+	})
+})
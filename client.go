@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/garyburd/go-oauth/oauth"
 )
@@ -16,7 +17,15 @@ type Client struct {
 	httpClient  HTTPClient
 	oauthClient *oauth.Client
 	accessCreds *oauth.Credentials
+	bearer      *bearerToken
+	baseURLs    BaseURLs
+	retry       *RetryPolicy
+	limiter     *RateLimiter
+	pool        *CredentialPool
+	streamOpts  streamOptions
+	logger      Logger
 
+	appOnly      bool
 	gzipDisabled bool
 }
 
@@ -39,6 +48,7 @@ func NewClient(consumerCreds ConsumerCredentials, accessCreds AccessCredentials,
 			Token:  accessCreds.Token,
 			Secret: accessCreds.Secret,
 		},
+		bearer: &bearerToken{},
 	}
 }
 
@@ -49,48 +59,170 @@ func (c *Client) WithGzipDisabled() *Client {
 	return &newC
 }
 
+// BaseURLs represents the base URLs (scheme and host, with an optional path
+// prefix) that the Client sends requests to. Empty fields use the default
+// Twitter hosts.
+type BaseURLs struct {
+	API     string
+	Stream  string
+	Upload  string
+	DataAPI string
+	Publish string
+}
+
+var defaultBaseURLs = BaseURLs{
+	API:     "https://api.twitter.com",
+	Stream:  "https://stream.twitter.com",
+	Upload:  "https://upload.twitter.com",
+	DataAPI: "https://data-api.twitter.com",
+	Publish: "https://publish.twitter.com",
+}
+
+// WithBaseURLs returns a new shallow copy of the Client that sends requests to
+// the provided base URLs. This can be used to target a proxy, a regional
+// gateway, or a local test server. Empty fields keep the Client's current base
+// URL.
+func (c *Client) WithBaseURLs(urls BaseURLs) *Client {
+	newC := *c
+	if urls.API != "" {
+		newC.baseURLs.API = urls.API
+	}
+	if urls.Stream != "" {
+		newC.baseURLs.Stream = urls.Stream
+	}
+	if urls.Upload != "" {
+		newC.baseURLs.Upload = urls.Upload
+	}
+	if urls.DataAPI != "" {
+		newC.baseURLs.DataAPI = urls.DataAPI
+	}
+	if urls.Publish != "" {
+		newC.baseURLs.Publish = urls.Publish
+	}
+	return &newC
+}
+
+func (c *Client) apiURL(path string) string {
+	return joinURL(c.baseURLs.API, defaultBaseURLs.API, path)
+}
+
+func (c *Client) streamURL(path string) string {
+	return joinURL(c.baseURLs.Stream, defaultBaseURLs.Stream, path)
+}
+
+func (c *Client) uploadURL(path string) string {
+	return joinURL(c.baseURLs.Upload, defaultBaseURLs.Upload, path)
+}
+
+func (c *Client) dataAPIURL(path string) string {
+	return joinURL(c.baseURLs.DataAPI, defaultBaseURLs.DataAPI, path)
+}
+
+func (c *Client) publishURL(path string) string {
+	return joinURL(c.baseURLs.Publish, defaultBaseURLs.Publish, path)
+}
+
+func joinURL(base, def, path string) string {
+	if base == "" {
+		base = def
+	}
+	return strings.TrimRight(base, "/") + path
+}
+
 // do readies the request body/query url for simple queries and calls execute
 func (c *Client) do(ctx context.Context, method, urlStr string, values url.Values) (*http.Response, error) {
-	// Set up request URL and body.
-	var body io.Reader
+	urlStr, body, values := encodeValues(method, urlStr, values)
+	return c.execute(ctx, method, urlStr, "application/x-www-form-urlencoded", body, values)
+}
+
+// encodeValues sets up the request URL and body for the provided values. GET
+// and HEAD requests carry the values in the URL; other methods send them as a
+// form encoded body, and the values are returned for OAuth signing.
+func encodeValues(method, urlStr string, values url.Values) (string, io.Reader, url.Values) {
 	switch method {
 	case "GET", "HEAD":
-		urlStr = urlStr + "?" + values.Encode()
-		values = nil
+		return urlStr + "?" + values.Encode(), nil, nil
 	default:
-		body = strings.NewReader(values.Encode())
+		return urlStr, strings.NewReader(values.Encode()), values
 	}
-
-	return c.execute(ctx, method, urlStr, "application/x-www-form-urlencoded", body, values)
 }
 
 // execute implements the OAuthClient interface. It is used to make an OAuth HTTP
 // request with the provided context, HTTP method, body ioReader, OAuth credentials, URL
 // string, and URL query parameters. It returns the corresponding HTTP response
-// or error.
+// or error. If the Client has a RetryPolicy, transient failures are retried.
 func (c *Client) execute(ctx context.Context, method, urlStr, contentType string, body io.Reader, values url.Values) (*http.Response, error) {
-	accessCreds := c.accessCredentials(ctx)
+	if c.retry != nil && c.retry.canRetry(method) {
+		return c.executeRetry(ctx, method, urlStr, contentType, body, values)
+	}
+	return c.executeOnce(ctx, method, urlStr, contentType, body, values)
+}
 
+// executeOnce makes a single attempt of the request described by execute.
+func (c *Client) executeOnce(ctx context.Context, method, urlStr, contentType string, body io.Reader, values url.Values) (resp *http.Response, err error) {
 	req, err := http.NewRequest(method, urlStr, body)
 	if err != nil {
 		return nil, err
 	}
+	ctx, err = c.withPooledCredentials(ctx, rateLimitResource(req.URL))
+	if err != nil {
+		return nil, err
+	}
 	req = req.WithContext(ctx)
+	accessCreds := c.accessCredentials(ctx)
+
+	// Wait for rate limit budget before signing so the OAuth timestamp is
+	// current when the request is sent.
+	if c.limiter != nil {
+		key := c.rateLimitKey(ctx, req.URL)
+		if err = c.limiter.wait(ctx, key); err != nil {
+			return nil, err
+		}
+		defer func() {
+			if resp != nil {
+				c.limiter.update(key, resp)
+			}
+		}()
+	}
 
 	req.Header.Set("Content-Type", contentType)
 	if !c.gzipDisabled {
 		req.Header.Set("Accept-Encoding", "gzip")
 	}
-	err = c.oauthClient.SetAuthorizationHeader(req.Header, accessCreds, req.Method, req.URL, values)
-	if err != nil {
-		return nil, err
+	var bearer string
+	if c.appOnly {
+		bearer, err = c.BearerToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	} else {
+		err = c.oauthClient.SetAuthorizationHeader(req.Header, accessCreds, req.Method, req.URL, values)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if c.gzipDisabled || err != nil || !isGzipped(resp.Header) {
-		return resp, err
+	start := time.Now()
+	resp, err = c.httpClient.Do(req)
+	if err != nil {
+		c.log().Warn("twitter: request failed", "method", method, "url", urlStr, "error", err)
+	} else {
+		c.log().Debug("twitter: request", "method", method, "url", urlStr,
+			"status", resp.StatusCode, "duration", time.Since(start))
+	}
+	if c.appOnly && err == nil && resp.StatusCode == 401 {
+		// The bearer token may have been invalidated elsewhere, so drop it
+		// and request a new one on the next call.
+		c.clearBearerToken(bearer)
+	}
+	if !c.gzipDisabled && err == nil && isGzipped(resp.Header) {
+		resp, err = gzipResponse(resp)
+	}
+	if c.pool != nil && err == nil && isInvalidToken(resp) {
+		c.pool.quarantine(accessCreds.Token)
 	}
-	return gzipResponse(resp)
+	return resp, err
 }
 
 // HTTPClient is the interface for making HTTP requests. It accepts an HTTP
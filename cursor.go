@@ -0,0 +1,275 @@
+package twitter
+
+import (
+	"context"
+	"strconv"
+)
+
+// IDsPageFn represents a function that fetches the page of IDs at the provided
+// cursor. An empty cursor requests the first page.
+type IDsPageFn func(ctx context.Context, cursor string) (*IDsResponse, error)
+
+// PagerParams represents the options for walking a cursored endpoint.
+type PagerParams struct {
+	// Cursor resumes paging from a previously saved cursor.
+	Cursor string
+	// MaxPages limits the number of pages requested. Zero means no limit.
+	MaxPages int
+	// MaxItems limits the number of items returned. Zero means no limit.
+	MaxItems int
+}
+
+// IDsPager walks any endpoint that returns the IDs model by following
+// next_cursor until it is 0. An IDsPager is not safe for concurrent use.
+type IDsPager struct {
+	cursorState
+	fn  IDsPageFn
+	err error
+}
+
+// NewIDsPager returns a new IDsPager that fetches pages using the provided
+// function.
+func NewIDsPager(fn IDsPageFn, params PagerParams) *IDsPager {
+	return &IDsPager{
+		cursorState: newCursorState(params),
+		fn:          fn,
+	}
+}
+
+// RetweeterIDsPager returns an IDsPager over the Twitter
+// /statuses/retweeters/ids.json endpoint.
+func (c *Client) RetweeterIDsPager(params RetweeterIDsParams, pagerParams PagerParams) *IDsPager {
+	return NewIDsPager(func(ctx context.Context, cursor string) (*IDsResponse, error) {
+		params.Cursor = cursor
+		return c.RetweeterIDs(ctx, params)
+	}, pagerParams)
+}
+
+// Next fetches and returns the next page of IDs. It returns an empty slice and
+// no error once paging is done.
+func (p *IDsPager) Next(ctx context.Context) ([]string, error) {
+	if !p.ready() {
+		return nil, nil
+	}
+	res, err := p.fn(ctx, p.cursor)
+	if err != nil {
+		return nil, err
+	}
+	ids := res.IDs.IDs
+	return ids[:p.advance(len(ids), nextCursor(res.IDs.NextCursorStr, res.IDs.NextCursor))], nil
+}
+
+// Each calls fn with every ID until paging is done, fn returns an error, or
+// the context is cancelled.
+func (p *IDsPager) Each(ctx context.Context, fn func(id string) error) error {
+	for !p.done {
+		ids, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err = fn(id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Chan returns a channel that every ID is sent to. The channel is closed when
+// paging is done, an error occurs, or the context is cancelled; Err returns the
+// error, if any, once the channel is closed.
+func (p *IDsPager) Chan(ctx context.Context) <-chan string {
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		p.err = p.Each(ctx, func(id string) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case ch <- id:
+				return nil
+			}
+		})
+	}()
+	return ch
+}
+
+// Err returns the error that stopped the channel returned by Chan.
+func (p *IDsPager) Err() error {
+	return p.err
+}
+
+// UsersPageFn represents a function that fetches the page of users at the
+// provided cursor. An empty cursor requests the first page.
+type UsersPageFn func(ctx context.Context, cursor string) (*CursoredUsersResponse, error)
+
+// UsersPager walks any endpoint that returns the cursored users model by
+// following next_cursor until it is 0. A UsersPager is not safe for concurrent
+// use.
+type UsersPager struct {
+	cursorState
+	fn UsersPageFn
+}
+
+// NewUsersPager returns a new UsersPager that fetches pages using the provided
+// function.
+func NewUsersPager(fn UsersPageFn, params PagerParams) *UsersPager {
+	return &UsersPager{
+		cursorState: newCursorState(params),
+		fn:          fn,
+	}
+}
+
+// Next fetches and returns the next page of users. It returns an empty slice
+// and no error once paging is done.
+func (p *UsersPager) Next(ctx context.Context) ([]User, error) {
+	if !p.ready() {
+		return nil, nil
+	}
+	res, err := p.fn(ctx, p.cursor)
+	if err != nil {
+		return nil, err
+	}
+	users := res.Users.Users
+	return users[:p.advance(len(users), nextCursor(res.Users.NextCursorStr, res.Users.NextCursor))], nil
+}
+
+// Each calls fn with every user until paging is done, fn returns an error, or
+// the context is cancelled.
+func (p *UsersPager) Each(ctx context.Context, fn func(User) error) error {
+	for !p.done {
+		users, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, u := range users {
+			if err = fn(u); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ListsPageFn represents a function that fetches the page of lists at the
+// provided cursor. An empty cursor requests the first page.
+type ListsPageFn func(ctx context.Context, cursor string) (*CursoredListsResponse, error)
+
+// ListsPager walks any endpoint that returns the cursored lists model by
+// following next_cursor until it is 0. A ListsPager is not safe for concurrent
+// use.
+type ListsPager struct {
+	cursorState
+	fn ListsPageFn
+}
+
+// NewListsPager returns a new ListsPager that fetches pages using the provided
+// function.
+func NewListsPager(fn ListsPageFn, params PagerParams) *ListsPager {
+	return &ListsPager{
+		cursorState: newCursorState(params),
+		fn:          fn,
+	}
+}
+
+// Next fetches and returns the next page of lists. It returns an empty slice
+// and no error once paging is done.
+func (p *ListsPager) Next(ctx context.Context) ([]List, error) {
+	if !p.ready() {
+		return nil, nil
+	}
+	res, err := p.fn(ctx, p.cursor)
+	if err != nil {
+		return nil, err
+	}
+	lists := res.Lists.Lists
+	return lists[:p.advance(len(lists), nextCursor(res.Lists.NextCursorStr, res.Lists.NextCursor))], nil
+}
+
+// Each calls fn with every list until paging is done, fn returns an error, or
+// the context is cancelled.
+func (p *ListsPager) Each(ctx context.Context, fn func(List) error) error {
+	for !p.done {
+		lists, err := p.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, l := range lists {
+			if err = fn(l); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// cursorState represents the position and limits of a cursored pager.
+type cursorState struct {
+	cursor   string
+	maxPages int
+	maxItems int
+	pages    int
+	items    int
+	done     bool
+}
+
+func newCursorState(params PagerParams) cursorState {
+	return cursorState{
+		cursor:   params.Cursor,
+		maxPages: params.MaxPages,
+		maxItems: params.MaxItems,
+	}
+}
+
+// Cursor returns the cursor of the next page to be fetched, which can be saved
+// and passed to PagerParams to resume paging later. If the last page was cut
+// short by MaxItems, Cursor still refers to that page.
+func (s *cursorState) Cursor() string {
+	return s.cursor
+}
+
+// Done returns whether there are no more pages to fetch.
+func (s *cursorState) Done() bool {
+	return s.done
+}
+
+// ready returns whether another page should be fetched.
+func (s *cursorState) ready() bool {
+	if s.maxPages > 0 && s.pages >= s.maxPages {
+		s.done = true
+	}
+	return !s.done
+}
+
+// advance records a fetched page of n items and returns how many of them to
+// keep under MaxItems.
+func (s *cursorState) advance(n int, next string) int {
+	s.pages++
+	if s.maxItems > 0 && s.items+n >= s.maxItems {
+		if s.items+n > s.maxItems {
+			n = s.maxItems - s.items
+		} else {
+			s.cursor = next
+		}
+		s.items += n
+		s.done = true
+		return n
+	}
+	s.items += n
+	s.cursor = next
+	if s.cursor == "0" {
+		s.done = true
+	}
+	return n
+}
+
+func nextCursor(str string, cursor int64) string {
+	if str != "" {
+		return str
+	}
+	return strconv.FormatInt(cursor, 10)
+}
@@ -0,0 +1,89 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cursor", func() {
+	pages := map[string]IDs{
+		"":    {IDs: []string{"1", "2"}, NextCursorStr: "100"},
+		"100": {IDs: []string{"3", "4"}, NextCursorStr: "200"},
+		"200": {IDs: []string{"5"}, NextCursorStr: "0"},
+	}
+	var requested []string
+	fn := func(ctx context.Context, cursor string) (*IDsResponse, error) {
+		requested = append(requested, cursor)
+		ids, ok := pages[cursor]
+		if !ok {
+			return nil, fmt.Errorf("unknown cursor %q", cursor)
+		}
+		return &IDsResponse{IDs: ids}, nil
+	}
+
+	BeforeEach(func() {
+		requested = nil
+	})
+
+	Context("IDsPager", func() {
+		It("should walk every page until the cursor is 0", func() {
+			var ids []string
+			pager := NewIDsPager(fn, PagerParams{})
+			err := pager.Each(context.Background(), func(id string) error {
+				ids = append(ids, id)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2", "3", "4", "5"}))
+			Ω(requested).Should(Equal([]string{"", "100", "200"}))
+			Ω(pager.Done()).Should(BeTrue())
+		})
+
+		It("should resume from a saved cursor and stop at MaxPages", func() {
+			pager := NewIDsPager(fn, PagerParams{Cursor: "100", MaxPages: 1})
+			ids, err := pager.Next(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"3", "4"}))
+			ids, err = pager.Next(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(BeEmpty())
+			Ω(pager.Cursor()).Should(Equal("200"))
+		})
+
+		It("should stop at MaxItems", func() {
+			var ids []string
+			pager := NewIDsPager(fn, PagerParams{MaxItems: 3})
+			for id := range pager.Chan(context.Background()) {
+				ids = append(ids, id)
+			}
+			Ω(pager.Err()).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2", "3"}))
+			Ω(pager.Cursor()).Should(Equal("100"))
+		})
+
+		It("should stop when fn returns an error", func() {
+			stop := errors.New("stop")
+			pager := NewIDsPager(fn, PagerParams{})
+			err := pager.Each(context.Background(), func(id string) error {
+				return stop
+			})
+			Ω(err).Should(Equal(stop))
+			Ω(requested).Should(HaveLen(1))
+		})
+
+		It("should stop when the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			pager := NewIDsPager(fn, PagerParams{})
+			ch := pager.Chan(ctx)
+			Ω(<-ch).Should(Equal("1"))
+			cancel()
+			for range ch {
+			}
+			Ω(pager.Err()).Should(Equal(context.Canceled))
+		})
+	})
+})
@@ -19,7 +19,7 @@ type GetDirectMessagesParams struct {
 // GetDirectMessages calls the Twitter /direct_messages.json endpoint.
 func (c *Client) GetDirectMessages(ctx context.Context, params GetDirectMessagesParams) (*DirectMessagesResponse, error) {
 	values := getDirectMessagesToQuery(params)
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/direct_messages.json", values)
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/direct_messages.json"), values)
 	if err != nil {
 		return nil, err
 	}
@@ -72,7 +72,7 @@ func (c *Client) DestroyDirectMessage(ctx context.Context, params DestroyDirectM
 	if params.ExcludeEntities {
 		values.Set("include_entities", "false")
 	}
-	resp, err := c.do(ctx, "POST", "https://api.twitter.com/1.1/direct_messages/destroy.json", values)
+	resp, err := c.do(ctx, "POST", c.apiURL("/1.1/direct_messages/destroy.json"), values)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +101,7 @@ type NewDirectMessageParams struct {
 // NewDirectMessage calls the Twitter /direct_messages/sent.json endpoint.
 func (c *Client) NewDirectMessage(ctx context.Context, params NewDirectMessageParams) (*DirectMessageResponse, error) {
 	values := newDirectMessageToQuery(params)
-	resp, err := c.do(ctx, "POST", "https://api.twitter.com/1.1/direct_messages/new.json", values)
+	resp, err := c.do(ctx, "POST", c.apiURL("/1.1/direct_messages/new.json"), values)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +145,7 @@ type SentDirectMessagesParams struct {
 // SentDirectMessages calls the Twitter /direct_messages/sent.json endpoint.
 func (c *Client) SentDirectMessages(ctx context.Context, params SentDirectMessagesParams) (*DirectMessagesResponse, error) {
 	values := sentDirectMessagesToQuery(params)
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/direct_messages/sent.json", values)
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/direct_messages/sent.json"), values)
 	if err != nil {
 		return nil, err
 	}
@@ -188,7 +188,7 @@ func sentDirectMessagesToQuery(params SentDirectMessagesParams) url.Values {
 // ShowDirectMessage calls the Twitter /direct_messages/show.json endpoint.
 func (c *Client) ShowDirectMessage(ctx context.Context, id string) (*DirectMessageResponse, error) {
 	values := url.Values{"id": []string{id}}
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/direct_messages/show.json", values)
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/direct_messages/show.json"), values)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +44,27 @@ func (e *Errors) Error() string {
 	return buf.String()
 }
 
+// errCodeInvalidToken is the Twitter error code for an invalid, expired or
+// revoked access token.
+const errCodeInvalidToken = 89
+
+func (e *Errors) hasCode(code int) bool {
+	for _, err := range e.Errors {
+		if err.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInvalidToken reports whether err is an error response from Twitter stating
+// that the access token used for the request is invalid, expired or has been
+// revoked by the user.
+func IsInvalidToken(err error) bool {
+	errs, ok := err.(*Errors)
+	return ok && errs.HTTPCode == 401 && errs.hasCode(errCodeInvalidToken)
+}
+
 func checkResponse(resp *http.Response) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return nil
@@ -20,7 +20,7 @@ type ListFavoritesParams struct {
 // ListFavorites calls the Twitter /favorites/list.json endpoint
 func (c *Client) ListFavorites(ctx context.Context, params ListFavoritesParams) (*TweetsResponse, error) {
 	values := listFavoritesToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/favorites/list.json"
+	urlStr := c.apiURL("/1.1/favorites/list.json")
 	return c.handleTweetsResponse(ctx, "GET", urlStr, values)
 }
 
@@ -57,7 +57,7 @@ type CreateFavoriteParameters struct {
 // CreateFavorite calls the Twitter /favorites/create.json endpoint
 func (c *Client) CreateFavorite(ctx context.Context, params CreateFavoriteParameters) (*TweetResponse, error) {
 	values := createFavoriteToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/favorites/create.json"
+	urlStr := c.apiURL("/1.1/favorites/create.json")
 	return c.handleTweetResponse(ctx, "POST", urlStr, values)
 }
 
@@ -80,7 +80,7 @@ type DestroyFavoriteParameters struct {
 // DestroyFavorite calls the Twitter /favorites/create.json endpoint
 func (c *Client) DestroyFavorite(ctx context.Context, params DestroyFavoriteParameters) (*TweetResponse, error) {
 	values := destroyFavoriteToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/favorites/destroy.json"
+	urlStr := c.apiURL("/1.1/favorites/destroy.json")
 	return c.handleTweetResponse(ctx, "POST", urlStr, values)
 }
 
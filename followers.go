@@ -0,0 +1,132 @@
+package twitter
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// GraphIDsParams represents the query parameters for a /followers/ids.json or
+// /friends/ids.json request.
+type GraphIDsParams struct {
+	UserID     string
+	ScreenName string
+	Cursor     string
+	Count      int
+}
+
+// FollowerIDs calls the Twitter /followers/ids.json endpoint.
+func (c *Client) FollowerIDs(ctx context.Context, params GraphIDsParams) (*IDsResponse, error) {
+	values := graphIDsToQuery(params)
+	urlStr := c.apiURL("/1.1/followers/ids.json")
+	return c.handleIDsResponse(ctx, "GET", urlStr, values)
+}
+
+// FriendIDs calls the Twitter /friends/ids.json endpoint.
+func (c *Client) FriendIDs(ctx context.Context, params GraphIDsParams) (*IDsResponse, error) {
+	values := graphIDsToQuery(params)
+	urlStr := c.apiURL("/1.1/friends/ids.json")
+	return c.handleIDsResponse(ctx, "GET", urlStr, values)
+}
+
+// FollowerIDsPager returns an IDsPager over the Twitter /followers/ids.json
+// endpoint.
+func (c *Client) FollowerIDsPager(params GraphIDsParams, pagerParams PagerParams) *IDsPager {
+	return NewIDsPager(func(ctx context.Context, cursor string) (*IDsResponse, error) {
+		params.Cursor = cursor
+		return c.FollowerIDs(ctx, params)
+	}, pagerParams)
+}
+
+// FriendIDsPager returns an IDsPager over the Twitter /friends/ids.json
+// endpoint.
+func (c *Client) FriendIDsPager(params GraphIDsParams, pagerParams PagerParams) *IDsPager {
+	return NewIDsPager(func(ctx context.Context, cursor string) (*IDsResponse, error) {
+		params.Cursor = cursor
+		return c.FriendIDs(ctx, params)
+	}, pagerParams)
+}
+
+func graphIDsToQuery(params GraphIDsParams) url.Values {
+	values := url.Values{}
+	if params.UserID != "" {
+		values.Set("user_id", params.UserID)
+	}
+	if params.ScreenName != "" {
+		values.Set("screen_name", params.ScreenName)
+	}
+	if params.Cursor != "" {
+		values.Set("cursor", params.Cursor)
+	}
+	if params.Count != 0 {
+		values.Set("count", strconv.Itoa(params.Count))
+	}
+	values.Set("stringify_ids", "true")
+	return values
+}
+
+// GraphListParams represents the query parameters for a /followers/list.json
+// or /friends/list.json request.
+type GraphListParams struct {
+	UserID              string
+	ScreenName          string
+	Cursor              string
+	Count               int
+	SkipStatus          bool
+	IncludeUserEntities bool
+}
+
+// FollowersList calls the Twitter /followers/list.json endpoint.
+func (c *Client) FollowersList(ctx context.Context, params GraphListParams) (*CursoredUsersResponse, error) {
+	values := graphListToQuery(params)
+	urlStr := c.apiURL("/1.1/followers/list.json")
+	return c.handleCursoredUsersResponse(ctx, "GET", urlStr, values)
+}
+
+// FriendsList calls the Twitter /friends/list.json endpoint.
+func (c *Client) FriendsList(ctx context.Context, params GraphListParams) (*CursoredUsersResponse, error) {
+	values := graphListToQuery(params)
+	urlStr := c.apiURL("/1.1/friends/list.json")
+	return c.handleCursoredUsersResponse(ctx, "GET", urlStr, values)
+}
+
+// FollowersListPager returns a UsersPager over the Twitter
+// /followers/list.json endpoint.
+func (c *Client) FollowersListPager(params GraphListParams, pagerParams PagerParams) *UsersPager {
+	return NewUsersPager(func(ctx context.Context, cursor string) (*CursoredUsersResponse, error) {
+		params.Cursor = cursor
+		return c.FollowersList(ctx, params)
+	}, pagerParams)
+}
+
+// FriendsListPager returns a UsersPager over the Twitter /friends/list.json
+// endpoint.
+func (c *Client) FriendsListPager(params GraphListParams, pagerParams PagerParams) *UsersPager {
+	return NewUsersPager(func(ctx context.Context, cursor string) (*CursoredUsersResponse, error) {
+		params.Cursor = cursor
+		return c.FriendsList(ctx, params)
+	}, pagerParams)
+}
+
+func graphListToQuery(params GraphListParams) url.Values {
+	values := url.Values{}
+	if params.UserID != "" {
+		values.Set("user_id", params.UserID)
+	}
+	if params.ScreenName != "" {
+		values.Set("screen_name", params.ScreenName)
+	}
+	if params.Cursor != "" {
+		values.Set("cursor", params.Cursor)
+	}
+	if params.Count != 0 {
+		values.Set("count", strconv.Itoa(params.Count))
+	}
+	if params.SkipStatus {
+		values.Set("skip_status", "true")
+	}
+	if params.IncludeUserEntities {
+		values.Set("include_user_entities", "true")
+	}
+	return values
+}
@@ -0,0 +1,228 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Followers", func() {
+	Context("FollowerIDs", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.FollowerIDs(context.Background(), GraphIDsParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/followers/ids.json"))
+					Ω(req.FormValue("screen_name")).Should(Equal("crowdriff"))
+					Ω(req.FormValue("count")).Should(Equal("5000"))
+					Ω(req.FormValue("stringify_ids")).Should(Equal("true"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"ids":["1","2"],"next_cursor_str":"0"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.FollowerIDs(context.Background(), GraphIDsParams{ScreenName: "crowdriff", Count: 5000})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.IDs.IDs).Should(Equal([]string{"1", "2"}))
+		})
+	})
+
+	Context("FriendIDs", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.FriendIDs(context.Background(), GraphIDsParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/friends/ids.json"))
+					Ω(req.FormValue("user_id")).Should(Equal("1"))
+					Ω(req.FormValue("stringify_ids")).Should(Equal("true"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"ids":["2","3"],"next_cursor_str":"0"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.FriendIDs(context.Background(), GraphIDsParams{UserID: "1"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.IDs.IDs).Should(Equal([]string{"2", "3"}))
+		})
+	})
+
+	Context("FriendIDsPager", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					if req.FormValue("cursor") == "" {
+						return &http.Response{
+							StatusCode: 200,
+							Body:       ioutil.NopCloser(strings.NewReader(`{"ids":["1","2"],"next_cursor_str":"100"}`)),
+						}, nil
+					}
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			var ids []string
+			err := client.FriendIDsPager(GraphIDsParams{UserID: "1"}, PagerParams{}).Each(context.Background(), func(id string) error {
+				ids = append(ids, id)
+				return nil
+			})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+			Ω(ids).Should(Equal([]string{"1", "2"}))
+		})
+
+		It("should walk every page", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/friends/ids.json"))
+					body := `{"ids":["1","2"],"next_cursor_str":"100"}`
+					if req.FormValue("cursor") != "" {
+						Ω(req.FormValue("cursor")).Should(Equal("100"))
+						body = `{"ids":["3"],"next_cursor_str":"0"}`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			var ids []string
+			err := client.FriendIDsPager(GraphIDsParams{UserID: "1"}, PagerParams{}).Each(context.Background(), func(id string) error {
+				ids = append(ids, id)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2", "3"}))
+		})
+	})
+
+	Context("FollowersList", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.FollowersList(context.Background(), GraphListParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/followers/list.json"))
+					Ω(req.FormValue("user_id")).Should(Equal("1"))
+					Ω(req.FormValue("skip_status")).Should(Equal("true"))
+					Ω(req.FormValue("include_user_entities")).Should(Equal("true"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"users":[{"id_str":"2"}],"next_cursor_str":"100"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.FollowersList(context.Background(), GraphListParams{
+				UserID:              "1",
+				SkipStatus:          true,
+				IncludeUserEntities: true,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Users.Users).Should(HaveLen(1))
+			Ω(res.Users.NextCursorStr).Should(Equal("100"))
+		})
+	})
+
+	Context("FriendsListPager", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			pager := client.FriendsListPager(GraphListParams{}, PagerParams{})
+			err := pager.Each(context.Background(), func(u User) error {
+				Fail("unexpected user")
+				return nil
+			})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+			Ω(pager.Cursor()).Should(BeEmpty())
+		})
+
+		It("should walk pages up to MaxItems", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/friends/list.json"))
+					body := `{"users":[{"id_str":"1"},{"id_str":"2"}],"next_cursor_str":"100"}`
+					if req.FormValue("cursor") != "" {
+						body = `{"users":[{"id_str":"3"},{"id_str":"4"}],"next_cursor_str":"0"}`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			pager := client.FriendsListPager(GraphListParams{}, PagerParams{MaxItems: 3})
+			var ids []string
+			err := pager.Each(context.Background(), func(u User) error {
+				ids = append(ids, u.IDStr)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2", "3"}))
+			Ω(pager.Done()).Should(BeTrue())
+			Ω(pager.Cursor()).Should(Equal("100"))
+		})
+	})
+})
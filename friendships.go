@@ -3,6 +3,7 @@ package twitter
 import (
 	"context"
 	"net/url"
+	"strconv"
 	"strings"
 )
 
@@ -17,7 +18,7 @@ type ShowFriendshipsParameters struct {
 // ShowFriendships calls the Twitter endpoint /friendships/show.json
 func (c *Client) ShowFriendships(ctx context.Context, params ShowFriendshipsParameters) (*FriendshipResponse, error) {
 	values := showFriendshipToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/friendships/show.json"
+	urlStr := c.apiURL("/1.1/friendships/show.json")
 	return c.handleFriendshipResponse(ctx, "GET", urlStr, values)
 }
 
@@ -47,7 +48,7 @@ type LookupFriendshipsParams struct {
 // LookupFriendships calls Twitter endpoint /friendships/lookup.json
 func (c *Client) LookupFriendships(ctx context.Context, params LookupFriendshipsParams) (*FriendshipLookupResponse, error) {
 	values := lookupFriendshipsToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/friendships/lookup.json"
+	urlStr := c.apiURL("/1.1/friendships/lookup.json")
 	return c.handleFriendshipsResponse(ctx, "GET", urlStr, values)
 }
 
@@ -57,3 +58,121 @@ func lookupFriendshipsToQuery(params LookupFriendshipsParams) url.Values {
 	values.Set("user_id", strings.Join(params.UserID, ","))
 	return values
 }
+
+// CreateFriendshipParams represents parameters for /friendships/create.json Twitter endpoint
+type CreateFriendshipParams struct {
+	UserID     string
+	ScreenName string
+	Follow     bool
+}
+
+// CreateFriendship calls Twitter endpoint /friendships/create.json
+func (c *Client) CreateFriendship(ctx context.Context, params CreateFriendshipParams) (*UserResponse, error) {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	if params.Follow {
+		values.Set("follow", "true")
+	}
+	urlStr := c.apiURL("/1.1/friendships/create.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
+
+// DestroyFriendshipParams represents parameters for /friendships/destroy.json Twitter endpoint
+type DestroyFriendshipParams struct {
+	UserID     string
+	ScreenName string
+}
+
+// DestroyFriendship calls Twitter endpoint /friendships/destroy.json
+func (c *Client) DestroyFriendship(ctx context.Context, params DestroyFriendshipParams) (*UserResponse, error) {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	urlStr := c.apiURL("/1.1/friendships/destroy.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
+
+// UpdateFriendshipParams represents parameters for /friendships/update.json
+// Twitter endpoint. Device and Retweets are left unchanged when nil.
+type UpdateFriendshipParams struct {
+	UserID     string
+	ScreenName string
+	Device     *bool
+	Retweets   *bool
+}
+
+// UpdateFriendship calls Twitter endpoint /friendships/update.json
+func (c *Client) UpdateFriendship(ctx context.Context, params UpdateFriendshipParams) (*FriendshipResponse, error) {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	if params.Device != nil {
+		values.Set("device", strconv.FormatBool(*params.Device))
+	}
+	if params.Retweets != nil {
+		values.Set("retweets", strconv.FormatBool(*params.Retweets))
+	}
+	urlStr := c.apiURL("/1.1/friendships/update.json")
+	return c.handleFriendshipResponse(ctx, "POST", urlStr, values)
+}
+
+func friendshipUserToQuery(userID, screenName string) url.Values {
+	values := url.Values{}
+	if userID != "" {
+		values.Set("user_id", userID)
+	}
+	if screenName != "" {
+		values.Set("screen_name", screenName)
+	}
+	return values
+}
+
+// PendingFriendshipsParams represents parameters for /friendships/incoming.json
+// and /friendships/outgoing.json Twitter endpoints
+type PendingFriendshipsParams struct {
+	Cursor string
+}
+
+// IncomingFriendships calls Twitter endpoint /friendships/incoming.json
+func (c *Client) IncomingFriendships(ctx context.Context, params PendingFriendshipsParams) (*IDsResponse, error) {
+	values := pendingFriendshipsToQuery(params)
+	urlStr := c.apiURL("/1.1/friendships/incoming.json")
+	return c.handleIDsResponse(ctx, "GET", urlStr, values)
+}
+
+// OutgoingFriendships calls Twitter endpoint /friendships/outgoing.json
+func (c *Client) OutgoingFriendships(ctx context.Context, params PendingFriendshipsParams) (*IDsResponse, error) {
+	values := pendingFriendshipsToQuery(params)
+	urlStr := c.apiURL("/1.1/friendships/outgoing.json")
+	return c.handleIDsResponse(ctx, "GET", urlStr, values)
+}
+
+// IncomingFriendshipsPager returns an IDsPager over the Twitter
+// /friendships/incoming.json endpoint.
+func (c *Client) IncomingFriendshipsPager(pagerParams PagerParams) *IDsPager {
+	return NewIDsPager(func(ctx context.Context, cursor string) (*IDsResponse, error) {
+		return c.IncomingFriendships(ctx, PendingFriendshipsParams{Cursor: cursor})
+	}, pagerParams)
+}
+
+// OutgoingFriendshipsPager returns an IDsPager over the Twitter
+// /friendships/outgoing.json endpoint.
+func (c *Client) OutgoingFriendshipsPager(pagerParams PagerParams) *IDsPager {
+	return NewIDsPager(func(ctx context.Context, cursor string) (*IDsResponse, error) {
+		return c.OutgoingFriendships(ctx, PendingFriendshipsParams{Cursor: cursor})
+	}, pagerParams)
+}
+
+func pendingFriendshipsToQuery(params PendingFriendshipsParams) url.Values {
+	values := url.Values{}
+	if params.Cursor != "" {
+		values.Set("cursor", params.Cursor)
+	}
+	values.Set("stringify_ids", "true")
+	return values
+}
+
+// NoRetweetsIDs calls Twitter endpoint /friendships/no_retweets/ids.json,
+// returning the IDs of users whose retweets the authenticating user has
+// disabled. The response is not paginated.
+func (c *Client) NoRetweetsIDs(ctx context.Context) (*IDsResponse, error) {
+	values := url.Values{}
+	values.Set("stringify_ids", "true")
+	urlStr := c.apiURL("/1.1/friendships/no_retweets/ids.json")
+	return c.handleIDListResponse(ctx, "GET", urlStr, values)
+}
@@ -150,4 +150,166 @@ var _ = Describe("Friendships", func() {
 			Ω(err).ShouldNot(HaveOccurred())
 		})
 	})
+
+	Context("CreateFriendship", func() {
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/friendships/create.json"))
+					Ω(req.FormValue("screen_name")).Should(Equal("crowdriff"))
+					Ω(req.FormValue("follow")).Should(Equal("true"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"screen_name":"crowdriff"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.CreateFriendship(context.Background(), CreateFriendshipParams{ScreenName: "crowdriff", Follow: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.User.ScreenName).Should(Equal("crowdriff"))
+		})
+
+		It("should return ErrNoCredentials when the pool is exhausted", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Fail("unexpected request")
+					return nil, nil
+				},
+			}
+			client := (&Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}).WithCredentialPool(NewCredentialPool(nil))
+			_, err := client.CreateFriendship(context.Background(), CreateFriendshipParams{ScreenName: "crowdriff"})
+			Ω(err).Should(Equal(ErrNoCredentials))
+		})
+	})
+
+	Context("DestroyFriendship", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/friendships/destroy.json"))
+					Ω(req.FormValue("user_id")).Should(Equal("1"))
+					return &http.Response{
+						StatusCode: 403,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 161, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.DestroyFriendship(context.Background(), DestroyFriendshipParams{UserID: "1"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return ErrNoCredentials when the pool is exhausted", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Fail("unexpected request")
+					return nil, nil
+				},
+			}
+			client := (&Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}).WithCredentialPool(NewCredentialPool(nil))
+			_, err := client.DestroyFriendship(context.Background(), DestroyFriendshipParams{UserID: "1"})
+			Ω(err).Should(Equal(ErrNoCredentials))
+		})
+	})
+
+	Context("UpdateFriendship", func() {
+		It("should only send the provided toggles", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/friendships/update.json"))
+					Ω(req.FormValue("retweets")).Should(Equal("false"))
+					_, ok := req.Form["device"]
+					Ω(ok).Should(BeFalse())
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"relationship":{"source":{"want_retweets":false,"notifications_enabled":true}}}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			retweets := false
+			res, err := client.UpdateFriendship(context.Background(), UpdateFriendshipParams{UserID: "1", Retweets: &retweets})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Friendship.Relationship.Source.NotificationsEnabled).Should(BeTrue())
+		})
+
+		It("should return ErrNoCredentials when the pool is exhausted", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Fail("unexpected request")
+					return nil, nil
+				},
+			}
+			client := (&Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}).WithCredentialPool(NewCredentialPool(nil))
+			_, err := client.UpdateFriendship(context.Background(), UpdateFriendshipParams{UserID: "1"})
+			Ω(err).Should(Equal(ErrNoCredentials))
+		})
+	})
+
+	Context("IncomingFriendshipsPager", func() {
+		It("should walk every page", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/friendships/incoming.json"))
+					Ω(req.FormValue("stringify_ids")).Should(Equal("true"))
+					body := `{"ids":["1"],"next_cursor_str":"100"}`
+					if req.FormValue("cursor") == "100" {
+						body = `{"ids":["2"],"next_cursor_str":"0"}`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			var ids []string
+			err := client.IncomingFriendshipsPager(PagerParams{}).Each(context.Background(), func(id string) error {
+				ids = append(ids, id)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2"}))
+		})
+	})
+
+	Context("OutgoingFriendships", func() {
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/friendships/outgoing.json"))
+					Ω(req.FormValue("cursor")).Should(Equal("100"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"ids":["3"],"next_cursor_str":"0"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.OutgoingFriendships(context.Background(), PendingFriendshipsParams{Cursor: "100"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.IDs.IDs).Should(Equal([]string{"3"}))
+		})
+	})
+
+	Context("NoRetweetsIDs", func() {
+		It("should decode the list of IDs", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/friendships/no_retweets/ids.json"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`["1","2"]`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.NoRetweetsIDs(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.IDs.IDs).Should(Equal([]string{"1", "2"}))
+		})
+	})
 })
@@ -0,0 +1,130 @@
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// GeoID calls the Twitter /geo/id/:place_id.json endpoint.
+func (c *Client) GeoID(ctx context.Context, placeID string) (*PlaceResponse, error) {
+	urlStr := c.apiURL("/1.1/geo/id/") + url.PathEscape(placeID) + ".json"
+	resp, err := c.do(ctx, "GET", urlStr, url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var place Place
+	err = json.NewDecoder(resp.Body).Decode(&place)
+	if err != nil {
+		return nil, err
+	}
+	return &PlaceResponse{
+		Place:     place,
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
+
+// ReverseGeocodeParams represents the query parameters for a
+// /geo/reverse_geocode.json request. Accuracy is a radius in meters (or with
+// an "ft" suffix, feet) and Granularity is one of "neighborhood", "city",
+// "admin" or "country".
+type ReverseGeocodeParams struct {
+	Location    Location
+	Accuracy    string
+	Granularity string
+	MaxResults  int
+}
+
+// ReverseGeocode calls the Twitter /geo/reverse_geocode.json endpoint. The ID
+// of a returned Place can be used as UpdateTweetParams.PlaceID.
+func (c *Client) ReverseGeocode(ctx context.Context, params ReverseGeocodeParams) (*PlacesResponse, error) {
+	values := url.Values{}
+	setGeoLocation(values, &params.Location)
+	setGeoFilters(values, params.Accuracy, params.Granularity, params.MaxResults)
+	return c.handlePlacesResponse(ctx, c.apiURL("/1.1/geo/reverse_geocode.json"), values)
+}
+
+// GeoSearchParams represents the query parameters for a /geo/search.json
+// request. At least one of Location, Query or IP must be provided.
+type GeoSearchParams struct {
+	Location        *Location
+	Query           string
+	IP              string
+	Accuracy        string
+	Granularity     string
+	MaxResults      int
+	ContainedWithin string
+}
+
+// GeoSearch calls the Twitter /geo/search.json endpoint.
+func (c *Client) GeoSearch(ctx context.Context, params GeoSearchParams) (*PlacesResponse, error) {
+	values := geoSearchToQuery(params)
+	return c.handlePlacesResponse(ctx, c.apiURL("/1.1/geo/search.json"), values)
+}
+
+func geoSearchToQuery(params GeoSearchParams) url.Values {
+	values := url.Values{}
+	setGeoLocation(values, params.Location)
+	if params.Query != "" {
+		values.Set("query", params.Query)
+	}
+	if params.IP != "" {
+		values.Set("ip", params.IP)
+	}
+	setGeoFilters(values, params.Accuracy, params.Granularity, params.MaxResults)
+	if params.ContainedWithin != "" {
+		values.Set("contained_within", params.ContainedWithin)
+	}
+	return values
+}
+
+func setGeoLocation(values url.Values, loc *Location) {
+	if loc == nil {
+		return
+	}
+	values.Set("lat", strconv.FormatFloat(loc.Lat, 'f', -1, 64))
+	values.Set("long", strconv.FormatFloat(loc.Long, 'f', -1, 64))
+}
+
+func setGeoFilters(values url.Values, accuracy, granularity string, maxResults int) {
+	if accuracy != "" {
+		values.Set("accuracy", accuracy)
+	}
+	if granularity != "" {
+		values.Set("granularity", granularity)
+	}
+	if maxResults > 0 {
+		values.Set("max_results", strconv.Itoa(maxResults))
+	}
+}
+
+type placesRes struct {
+	Result struct {
+		Places []Place `json:"places"`
+	} `json:"result"`
+}
+
+func (c *Client) handlePlacesResponse(ctx context.Context, urlStr string, values url.Values) (*PlacesResponse, error) {
+	resp, err := c.do(ctx, "GET", urlStr, values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var res placesRes
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	if err != nil {
+		return nil, err
+	}
+	return &PlacesResponse{
+		Places:    res.Result.Places,
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
@@ -0,0 +1,115 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Geo", func() {
+	Context("GeoID", func() {
+		It("should return the place", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/geo/id/3797791ff9c0e4c6.json"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id":"3797791ff9c0e4c6","full_name":"Toronto, Ontario","bounding_box":{"type":"Polygon","coordinates":[[[-79.6,43.5],[-79.1,43.5],[-79.1,43.8],[-79.6,43.8]]]}}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.GeoID(context.Background(), "3797791ff9c0e4c6")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Place.FullName).Should(Equal("Toronto, Ontario"))
+			Ω(res.Place.BoundingBox.Coordinates[0]).Should(HaveLen(4))
+		})
+
+		It("should escape the place ID", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.EscapedPath()).Should(Equal("/1.1/geo/id/..%2Fstatuses%2Fx%3Fy.json"))
+					Ω(req.URL.RawQuery).Should(BeEmpty())
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.GeoID(context.Background(), "../statuses/x?y")
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("ReverseGeocode", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.ReverseGeocode(context.Background(), ReverseGeocodeParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return the places", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/geo/reverse_geocode.json"))
+					Ω(req.FormValue("lat")).Should(Equal("43.65"))
+					Ω(req.FormValue("long")).Should(Equal("-79.38"))
+					Ω(req.FormValue("granularity")).Should(Equal("city"))
+					Ω(req.FormValue("max_results")).Should(Equal("1"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"query":{},"result":{"places":[{"id":"3797791ff9c0e4c6","place_type":"city"}]}}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.ReverseGeocode(context.Background(), ReverseGeocodeParams{
+				Location:    Location{Lat: 43.65, Long: -79.38},
+				Granularity: "city",
+				MaxResults:  1,
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Places).Should(HaveLen(1))
+			Ω(res.Places[0].ID).Should(Equal("3797791ff9c0e4c6"))
+		})
+	})
+
+	Context("GeoSearch", func() {
+		It("should search by query without coordinates", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/geo/search.json"))
+					Ω(req.FormValue("query")).Should(Equal("Toronto"))
+					Ω(req.FormValue("contained_within")).Should(Equal("3376992a082d67c7"))
+					Ω(req.Form).ShouldNot(HaveKey("lat"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"result":{"places":[{"id":"1"},{"id":"2"}]}}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.GeoSearch(context.Background(), GeoSearchParams{
+				Query:           "Toronto",
+				ContainedWithin: "3376992a082d67c7",
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Places).Should(HaveLen(2))
+		})
+	})
+})
@@ -8,7 +8,7 @@ import (
 
 // GetConfiguration calls the Twitter /help/configuration.json endpoint.
 func (c *Client) GetConfiguration(ctx context.Context) (*ConfigurationResponse, error) {
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/help/configuration.json", url.Values{})
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/help/configuration.json"), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +29,7 @@ func (c *Client) GetConfiguration(ctx context.Context) (*ConfigurationResponse,
 
 // GetLanguages calls Twitter help/langauges.json endpoint.
 func (c *Client) GetLanguages(ctx context.Context) (*LanguagesResponse, error) {
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/help/langauges.json", url.Values{})
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/help/langauges.json"), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +50,7 @@ func (c *Client) GetLanguages(ctx context.Context) (*LanguagesResponse, error) {
 
 // GetPrivacy calls Twitter help/privacy.json endpoint.
 func (c *Client) GetPrivacy(ctx context.Context) (*PrivacyResponse, error) {
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/help/privacy.json", url.Values{})
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/help/privacy.json"), url.Values{})
 	if err != nil {
 		return nil, err
 	}
@@ -71,7 +71,7 @@ func (c *Client) GetPrivacy(ctx context.Context) (*PrivacyResponse, error) {
 
 // GetTOS calls Twitter help/tos.json endpoint.
 func (c *Client) GetTOS(ctx context.Context) (*TOSResponse, error) {
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/help/tos.json", url.Values{})
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/help/tos.json"), url.Values{})
 	if err != nil {
 		return nil, err
 	}
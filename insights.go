@@ -37,7 +37,7 @@ var groupings = []string{"tweet.id", "engagement.type"}
 //GetTotalPostInsights calls the twitter data api and retrieves insight totals (lifetime metrics) for up to 250 post ids
 // Posts older than 90 days cannot be queried using this endpoint
 func (c *Client) GetTotalPostInsights(ctx context.Context, params PostInsightsParams) (*PostInsightsResponse, error) {
-	urlStr := "https://data-api.twitter.com/insights/engagement/totals"
+	urlStr := c.dataAPIURL("/insights/engagement/totals")
 	query, err := insightsToQuery(params)
 	if err != nil {
 		return nil, err
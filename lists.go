@@ -0,0 +1,385 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// maxListMembersPerRequest is the maximum number of users that can be added to
+// or removed from a list in a single /lists/members/create_all.json or
+// /lists/members/destroy_all.json request.
+const maxListMembersPerRequest = 100
+
+// ListIdentifier identifies a list either by its ID or by its slug together
+// with the owner's ID or screen name.
+type ListIdentifier struct {
+	ListID          string
+	Slug            string
+	OwnerID         string
+	OwnerScreenName string
+}
+
+func listIdentifierToQuery(values url.Values, id ListIdentifier) {
+	if id.ListID != "" {
+		values.Set("list_id", id.ListID)
+	}
+	if id.Slug != "" {
+		values.Set("slug", id.Slug)
+	}
+	if id.OwnerID != "" {
+		values.Set("owner_id", id.OwnerID)
+	}
+	if id.OwnerScreenName != "" {
+		values.Set("owner_screen_name", id.OwnerScreenName)
+	}
+}
+
+// ListsParams represents parameters for /lists/list.json Twitter endpoint
+type ListsParams struct {
+	UserID     string
+	ScreenName string
+	Reverse    bool
+}
+
+// Lists calls Twitter endpoint /lists/list.json
+func (c *Client) Lists(ctx context.Context, params ListsParams) (*ListsResponse, error) {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	if params.Reverse {
+		values.Set("reverse", "true")
+	}
+	urlStr := c.apiURL("/1.1/lists/list.json")
+	return c.handleListsResponse(ctx, "GET", urlStr, values)
+}
+
+// ShowList calls Twitter endpoint /lists/show.json
+func (c *Client) ShowList(ctx context.Context, id ListIdentifier) (*ListResponse, error) {
+	values := url.Values{}
+	listIdentifierToQuery(values, id)
+	urlStr := c.apiURL("/1.1/lists/show.json")
+	return c.handleListResponse(ctx, "GET", urlStr, values)
+}
+
+// CreateListParams represents parameters for /lists/create.json Twitter
+// endpoint. Mode is either "public" (the default) or "private".
+type CreateListParams struct {
+	Name        string
+	Mode        string
+	Description string
+}
+
+// CreateList calls Twitter endpoint /lists/create.json
+func (c *Client) CreateList(ctx context.Context, params CreateListParams) (*ListResponse, error) {
+	values := url.Values{}
+	values.Set("name", params.Name)
+	if params.Mode != "" {
+		values.Set("mode", params.Mode)
+	}
+	if params.Description != "" {
+		values.Set("description", params.Description)
+	}
+	urlStr := c.apiURL("/1.1/lists/create.json")
+	return c.handleListResponse(ctx, "POST", urlStr, values)
+}
+
+// UpdateListParams represents parameters for /lists/update.json Twitter
+// endpoint. Only the non-empty fields are updated.
+type UpdateListParams struct {
+	ListIdentifier
+	Name        string
+	Mode        string
+	Description string
+}
+
+// UpdateList calls Twitter endpoint /lists/update.json
+func (c *Client) UpdateList(ctx context.Context, params UpdateListParams) (*ListResponse, error) {
+	values := url.Values{}
+	listIdentifierToQuery(values, params.ListIdentifier)
+	if params.Name != "" {
+		values.Set("name", params.Name)
+	}
+	if params.Mode != "" {
+		values.Set("mode", params.Mode)
+	}
+	if params.Description != "" {
+		values.Set("description", params.Description)
+	}
+	urlStr := c.apiURL("/1.1/lists/update.json")
+	return c.handleListResponse(ctx, "POST", urlStr, values)
+}
+
+// DestroyList calls Twitter endpoint /lists/destroy.json
+func (c *Client) DestroyList(ctx context.Context, id ListIdentifier) (*ListResponse, error) {
+	values := url.Values{}
+	listIdentifierToQuery(values, id)
+	urlStr := c.apiURL("/1.1/lists/destroy.json")
+	return c.handleListResponse(ctx, "POST", urlStr, values)
+}
+
+// ListStatusesParams represents parameters for /lists/statuses.json Twitter
+// endpoint
+type ListStatusesParams struct {
+	ListIdentifier
+	SinceID         string
+	MaxID           string
+	Count           int
+	ExcludeEntities bool
+	ExcludeRTS      bool
+}
+
+// ListStatuses calls Twitter endpoint /lists/statuses.json
+func (c *Client) ListStatuses(ctx context.Context, params ListStatusesParams) (*TweetsResponse, error) {
+	values := listStatusesToQuery(params)
+	urlStr := c.apiURL("/1.1/lists/statuses.json")
+	return c.handleTweetsResponse(ctx, "GET", urlStr, values)
+}
+
+func listStatusesToQuery(params ListStatusesParams) url.Values {
+	values := url.Values{}
+	listIdentifierToQuery(values, params.ListIdentifier)
+	if params.SinceID != "" {
+		values.Set("since_id", params.SinceID)
+	}
+	if params.MaxID != "" {
+		values.Set("max_id", params.MaxID)
+	}
+	if params.Count > 0 {
+		values.Set("count", strconv.Itoa(params.Count))
+	}
+	if params.ExcludeEntities {
+		values.Set("include_entities", "false")
+	}
+	if params.ExcludeRTS {
+		values.Set("include_rts", "false")
+	}
+	return values
+}
+
+// ListUsersParams represents parameters for /lists/members.json and
+// /lists/subscribers.json Twitter endpoints
+type ListUsersParams struct {
+	ListIdentifier
+	Cursor          string
+	Count           int
+	ExcludeEntities bool
+	SkipStatus      bool
+}
+
+// ListMembers calls Twitter endpoint /lists/members.json
+func (c *Client) ListMembers(ctx context.Context, params ListUsersParams) (*CursoredUsersResponse, error) {
+	values := listUsersToQuery(params)
+	urlStr := c.apiURL("/1.1/lists/members.json")
+	return c.handleCursoredUsersResponse(ctx, "GET", urlStr, values)
+}
+
+// ListSubscribers calls Twitter endpoint /lists/subscribers.json
+func (c *Client) ListSubscribers(ctx context.Context, params ListUsersParams) (*CursoredUsersResponse, error) {
+	values := listUsersToQuery(params)
+	urlStr := c.apiURL("/1.1/lists/subscribers.json")
+	return c.handleCursoredUsersResponse(ctx, "GET", urlStr, values)
+}
+
+// ListMembersPager returns a UsersPager over the Twitter /lists/members.json
+// endpoint.
+func (c *Client) ListMembersPager(params ListUsersParams, pagerParams PagerParams) *UsersPager {
+	return NewUsersPager(func(ctx context.Context, cursor string) (*CursoredUsersResponse, error) {
+		params.Cursor = cursor
+		return c.ListMembers(ctx, params)
+	}, pagerParams)
+}
+
+// ListSubscribersPager returns a UsersPager over the Twitter
+// /lists/subscribers.json endpoint.
+func (c *Client) ListSubscribersPager(params ListUsersParams, pagerParams PagerParams) *UsersPager {
+	return NewUsersPager(func(ctx context.Context, cursor string) (*CursoredUsersResponse, error) {
+		params.Cursor = cursor
+		return c.ListSubscribers(ctx, params)
+	}, pagerParams)
+}
+
+func listUsersToQuery(params ListUsersParams) url.Values {
+	values := url.Values{}
+	listIdentifierToQuery(values, params.ListIdentifier)
+	if params.Cursor != "" {
+		values.Set("cursor", params.Cursor)
+	}
+	if params.Count > 0 {
+		values.Set("count", strconv.Itoa(params.Count))
+	}
+	if params.ExcludeEntities {
+		values.Set("include_entities", "false")
+	}
+	if params.SkipStatus {
+		values.Set("skip_status", "true")
+	}
+	return values
+}
+
+// ListMemberParams represents parameters for /lists/members/show.json,
+// /lists/members/create.json and /lists/members/destroy.json Twitter endpoints
+type ListMemberParams struct {
+	ListIdentifier
+	UserID     string
+	ScreenName string
+}
+
+// ShowListMember calls Twitter endpoint /lists/members/show.json. Twitter
+// responds with an error if the user is not a member of the list.
+func (c *Client) ShowListMember(ctx context.Context, params ListMemberParams) (*UserResponse, error) {
+	values := listMemberToQuery(params)
+	urlStr := c.apiURL("/1.1/lists/members/show.json")
+	return c.handleUserResponse(ctx, "GET", urlStr, values)
+}
+
+// CreateListMember calls Twitter endpoint /lists/members/create.json
+func (c *Client) CreateListMember(ctx context.Context, params ListMemberParams) (*ListResponse, error) {
+	values := listMemberToQuery(params)
+	urlStr := c.apiURL("/1.1/lists/members/create.json")
+	return c.handleListResponse(ctx, "POST", urlStr, values)
+}
+
+// DestroyListMember calls Twitter endpoint /lists/members/destroy.json
+func (c *Client) DestroyListMember(ctx context.Context, params ListMemberParams) (*ListResponse, error) {
+	values := listMemberToQuery(params)
+	urlStr := c.apiURL("/1.1/lists/members/destroy.json")
+	return c.handleListResponse(ctx, "POST", urlStr, values)
+}
+
+func listMemberToQuery(params ListMemberParams) url.Values {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	listIdentifierToQuery(values, params.ListIdentifier)
+	return values
+}
+
+// ListMembersParams represents parameters for /lists/members/create_all.json
+// and /lists/members/destroy_all.json Twitter endpoints
+type ListMembersParams struct {
+	ListIdentifier
+	UserIDs     []string
+	ScreenNames []string
+}
+
+// CreateListMembers calls Twitter endpoint /lists/members/create_all.json,
+// splitting the users into as many requests as needed to stay within the
+// limit of 100 users per request. It returns the response of the last
+// request. If a request fails, the users sent in earlier requests have already
+// been added. At least one user ID or screen name is required.
+func (c *Client) CreateListMembers(ctx context.Context, params ListMembersParams) (*ListResponse, error) {
+	urlStr := c.apiURL("/1.1/lists/members/create_all.json")
+	return c.handleListMembersRequests(ctx, urlStr, params)
+}
+
+// DestroyListMembers calls Twitter endpoint /lists/members/destroy_all.json,
+// splitting the users into as many requests as needed to stay within the
+// limit of 100 users per request. It returns the response of the last
+// request. If a request fails, the users sent in earlier requests have already
+// been removed. At least one user ID or screen name is required.
+func (c *Client) DestroyListMembers(ctx context.Context, params ListMembersParams) (*ListResponse, error) {
+	urlStr := c.apiURL("/1.1/lists/members/destroy_all.json")
+	return c.handleListMembersRequests(ctx, urlStr, params)
+}
+
+func (c *Client) handleListMembersRequests(ctx context.Context, urlStr string, params ListMembersParams) (*ListResponse, error) {
+	queries := listMembersToQueries(params)
+	if len(queries) == 0 {
+		return nil, errors.New("twitter: UserIDs or ScreenNames required")
+	}
+	var res *ListResponse
+	for _, values := range queries {
+		var err error
+		res, err = c.handleListResponse(ctx, "POST", urlStr, values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// listMembersToQueries returns the query for each request needed to send all
+// of the users in params, with no more than maxListMembersPerRequest users in
+// any one request. User IDs and screen names are never mixed in a request.
+func listMembersToQueries(params ListMembersParams) []url.Values {
+	var queries []url.Values
+	add := func(key string, users []string) {
+		for start := 0; start < len(users); start += maxListMembersPerRequest {
+			end := start + maxListMembersPerRequest
+			if end > len(users) {
+				end = len(users)
+			}
+			values := url.Values{}
+			listIdentifierToQuery(values, params.ListIdentifier)
+			values.Set(key, strings.Join(users[start:end], ","))
+			queries = append(queries, values)
+		}
+	}
+	add("user_id", params.UserIDs)
+	add("screen_name", params.ScreenNames)
+	return queries
+}
+
+// ListMembershipsParams represents parameters for /lists/memberships.json
+// Twitter endpoint
+type ListMembershipsParams struct {
+	UserID             string
+	ScreenName         string
+	Cursor             string
+	Count              int
+	FilterToOwnedLists bool
+}
+
+// ListMemberships calls Twitter endpoint /lists/memberships.json
+func (c *Client) ListMemberships(ctx context.Context, params ListMembershipsParams) (*CursoredListsResponse, error) {
+	values := listsPageToQuery(params.UserID, params.ScreenName, params.Cursor, params.Count)
+	if params.FilterToOwnedLists {
+		values.Set("filter_to_owned_lists", "true")
+	}
+	urlStr := c.apiURL("/1.1/lists/memberships.json")
+	return c.handleCursoredListsResponse(ctx, "GET", urlStr, values)
+}
+
+// ListMembershipsPager returns a ListsPager over the Twitter
+// /lists/memberships.json endpoint.
+func (c *Client) ListMembershipsPager(params ListMembershipsParams, pagerParams PagerParams) *ListsPager {
+	return NewListsPager(func(ctx context.Context, cursor string) (*CursoredListsResponse, error) {
+		params.Cursor = cursor
+		return c.ListMemberships(ctx, params)
+	}, pagerParams)
+}
+
+// ListOwnershipsParams represents parameters for /lists/ownerships.json
+// Twitter endpoint
+type ListOwnershipsParams struct {
+	UserID     string
+	ScreenName string
+	Cursor     string
+	Count      int
+}
+
+// ListOwnerships calls Twitter endpoint /lists/ownerships.json
+func (c *Client) ListOwnerships(ctx context.Context, params ListOwnershipsParams) (*CursoredListsResponse, error) {
+	values := listsPageToQuery(params.UserID, params.ScreenName, params.Cursor, params.Count)
+	urlStr := c.apiURL("/1.1/lists/ownerships.json")
+	return c.handleCursoredListsResponse(ctx, "GET", urlStr, values)
+}
+
+// ListOwnershipsPager returns a ListsPager over the Twitter
+// /lists/ownerships.json endpoint.
+func (c *Client) ListOwnershipsPager(params ListOwnershipsParams, pagerParams PagerParams) *ListsPager {
+	return NewListsPager(func(ctx context.Context, cursor string) (*CursoredListsResponse, error) {
+		params.Cursor = cursor
+		return c.ListOwnerships(ctx, params)
+	}, pagerParams)
+}
+
+func listsPageToQuery(userID, screenName, cursor string, count int) url.Values {
+	values := friendshipUserToQuery(userID, screenName)
+	if cursor != "" {
+		values.Set("cursor", cursor)
+	}
+	if count > 0 {
+		values.Set("count", strconv.Itoa(count))
+	}
+	return values
+}
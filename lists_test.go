@@ -0,0 +1,605 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lists", func() {
+	Context("CreateList", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.CreateList(context.Background(), CreateListParams{Name: "team"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/create.json"))
+					Ω(req.FormValue("name")).Should(Equal("team"))
+					Ω(req.FormValue("mode")).Should(Equal("private"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1","slug":"team","member_count":3}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.CreateList(context.Background(), CreateListParams{Name: "team", Mode: "private"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.List.IDStr).Should(Equal("1"))
+			Ω(res.List.MemberCount).Should(Equal(3))
+		})
+	})
+
+	Context("ShowList", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.ShowList(context.Background(), ListIdentifier{ListID: "1"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("GET"))
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/show.json"))
+					Ω(req.FormValue("slug")).Should(Equal("team"))
+					Ω(req.FormValue("owner_screen_name")).Should(Equal("crowdriff"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1","slug":"team"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.ShowList(context.Background(), ListIdentifier{Slug: "team", OwnerScreenName: "crowdriff"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.List.Slug).Should(Equal("team"))
+		})
+	})
+
+	Context("UpdateList", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.UpdateList(context.Background(), UpdateListParams{ListIdentifier: ListIdentifier{ListID: "1"}})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					req.ParseForm()
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/update.json"))
+					Ω(req.FormValue("list_id")).Should(Equal("1"))
+					Ω(req.FormValue("description")).Should(Equal("the team"))
+					Ω(req.Form).ShouldNot(HaveKey("name"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1","description":"the team"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.UpdateList(context.Background(), UpdateListParams{ListIdentifier: ListIdentifier{ListID: "1"}, Description: "the team"})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("DestroyList", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.DestroyList(context.Background(), ListIdentifier{ListID: "1"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/destroy.json"))
+					Ω(req.FormValue("list_id")).Should(Equal("1"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.DestroyList(context.Background(), ListIdentifier{ListID: "1"})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("Lists", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.Lists(context.Background(), ListsParams{})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/list.json"))
+					Ω(req.FormValue("screen_name")).Should(Equal("crowdriff"))
+					Ω(req.FormValue("reverse")).Should(Equal("true"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`[{"id_str":"1"},{"id_str":"2"}]`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.Lists(context.Background(), ListsParams{ScreenName: "crowdriff", Reverse: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Lists).Should(HaveLen(2))
+		})
+	})
+
+	Context("ListStatusesWalker", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.ListStatusesWalker(ListStatusesParams{ListIdentifier: ListIdentifier{ListID: "1"}}, TimelineParams{}).Next(context.Background())
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should walk the list timeline", func() {
+			var maxIDs []string
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/statuses.json"))
+					Ω(req.FormValue("list_id")).Should(Equal("1"))
+					maxIDs = append(maxIDs, req.FormValue("max_id"))
+					body := `[]`
+					if req.FormValue("max_id") == "" {
+						body = `[{"id_str":"3"},{"id_str":"2"}]`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			var ids []string
+			err := client.ListStatusesWalker(ListStatusesParams{ListIdentifier: ListIdentifier{ListID: "1"}}, TimelineParams{}).Each(context.Background(), func(t Tweet) error {
+				ids = append(ids, t.IDStr)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"3", "2"}))
+			Ω(maxIDs).Should(Equal([]string{"", "1"}))
+		})
+	})
+
+	Context("ShowListMember", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 404,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 109, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.ShowListMember(context.Background(), ListMemberParams{ListIdentifier: ListIdentifier{ListID: "1"}, UserID: "2"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("GET"))
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/members/show.json"))
+					Ω(req.FormValue("list_id")).Should(Equal("1"))
+					Ω(req.FormValue("user_id")).Should(Equal("2"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"2"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.ShowListMember(context.Background(), ListMemberParams{ListIdentifier: ListIdentifier{ListID: "1"}, UserID: "2"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.User.IDStr).Should(Equal("2"))
+		})
+	})
+
+	Context("CreateListMember", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.CreateListMember(context.Background(), ListMemberParams{ListIdentifier: ListIdentifier{ListID: "1"}, ScreenName: "a"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/members/create.json"))
+					Ω(req.FormValue("list_id")).Should(Equal("1"))
+					Ω(req.FormValue("screen_name")).Should(Equal("a"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1","member_count":4}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.CreateListMember(context.Background(), ListMemberParams{ListIdentifier: ListIdentifier{ListID: "1"}, ScreenName: "a"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.List.MemberCount).Should(Equal(4))
+		})
+	})
+
+	Context("DestroyListMember", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.DestroyListMember(context.Background(), ListMemberParams{ListIdentifier: ListIdentifier{ListID: "1"}, ScreenName: "a"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/members/destroy.json"))
+					Ω(req.FormValue("list_id")).Should(Equal("1"))
+					Ω(req.FormValue("screen_name")).Should(Equal("a"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1","member_count":2}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.DestroyListMember(context.Background(), ListMemberParams{ListIdentifier: ListIdentifier{ListID: "1"}, ScreenName: "a"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.List.MemberCount).Should(Equal(2))
+		})
+	})
+
+	Context("CreateListMembers", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.CreateListMembers(context.Background(), ListMembersParams{
+				ListIdentifier: ListIdentifier{ListID: "1"},
+				ScreenNames:    []string{"a"},
+			})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should require users", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Fail("unexpected request")
+					return nil, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.CreateListMembers(context.Background(), ListMembersParams{
+				ListIdentifier: ListIdentifier{ListID: "1"},
+			})
+			Ω(res).Should(BeNil())
+			Ω(err).Should(MatchError("twitter: UserIDs or ScreenNames required"))
+		})
+
+		It("should chunk users into 100 per request", func() {
+			var requests []*http.Request
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					req.ParseForm()
+					requests = append(requests, req)
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			ids := make([]string, 250)
+			for i := range ids {
+				ids[i] = strconv.Itoa(i)
+			}
+			_, err := client.CreateListMembers(context.Background(), ListMembersParams{
+				ListIdentifier: ListIdentifier{ListID: "1"},
+				UserIDs:        ids,
+				ScreenNames:    []string{"a", "b"},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(requests).Should(HaveLen(4))
+			for _, req := range requests {
+				Ω(req.Method).Should(Equal("POST"))
+				Ω(req.URL.Path).Should(Equal("/1.1/lists/members/create_all.json"))
+				Ω(req.FormValue("list_id")).Should(Equal("1"))
+			}
+			Ω(strings.Split(requests[0].FormValue("user_id"), ",")).Should(Equal(ids[:100]))
+			Ω(strings.Split(requests[1].FormValue("user_id"), ",")).Should(Equal(ids[100:200]))
+			Ω(strings.Split(requests[2].FormValue("user_id"), ",")).Should(Equal(ids[200:]))
+			Ω(requests[3].FormValue("screen_name")).Should(Equal("a,b"))
+			Ω(requests[3].Form).ShouldNot(HaveKey("user_id"))
+		})
+	})
+
+	Context("DestroyListMembers", func() {
+		It("should stop at the first error", func() {
+			var requests []*http.Request
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req)
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.DestroyListMembers(context.Background(), ListMembersParams{
+				ListIdentifier: ListIdentifier{ListID: "1"},
+				UserIDs:        make([]string, 150),
+			})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+			Ω(requests).Should(HaveLen(1))
+			Ω(requests[0].URL.Path).Should(Equal("/1.1/lists/members/destroy_all.json"))
+		})
+
+		It("should require users", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Fail("unexpected request")
+					return nil, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.DestroyListMembers(context.Background(), ListMembersParams{
+				ListIdentifier: ListIdentifier{ListID: "1"},
+			})
+			Ω(err).Should(MatchError("twitter: UserIDs or ScreenNames required"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/members/destroy_all.json"))
+					Ω(req.FormValue("screen_name")).Should(Equal("a,b"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1","member_count":1}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.DestroyListMembers(context.Background(), ListMembersParams{
+				ListIdentifier: ListIdentifier{ListID: "1"},
+				ScreenNames:    []string{"a", "b"},
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.List.MemberCount).Should(Equal(1))
+		})
+	})
+
+	Context("ListSubscribersPager", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			err := client.ListSubscribersPager(ListUsersParams{ListIdentifier: ListIdentifier{ListID: "1"}}, PagerParams{}).Each(context.Background(), func(u User) error {
+				Fail("unexpected user")
+				return nil
+			})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should walk every page", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/subscribers.json"))
+					Ω(req.FormValue("list_id")).Should(Equal("1"))
+					body := `{"users":[{"id_str":"1"}],"next_cursor_str":"100"}`
+					if req.FormValue("cursor") != "" {
+						Ω(req.FormValue("cursor")).Should(Equal("100"))
+						body = `{"users":[{"id_str":"2"}],"next_cursor_str":"0"}`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			var ids []string
+			err := client.ListSubscribersPager(ListUsersParams{ListIdentifier: ListIdentifier{ListID: "1"}}, PagerParams{}).Each(context.Background(), func(u User) error {
+				ids = append(ids, u.IDStr)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2"}))
+		})
+	})
+
+	Context("ListMembershipsPager", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			err := client.ListMembershipsPager(ListMembershipsParams{ScreenName: "crowdriff"}, PagerParams{}).Each(context.Background(), func(l List) error {
+				Fail("unexpected list")
+				return nil
+			})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should walk every page", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/memberships.json"))
+					Ω(req.FormValue("filter_to_owned_lists")).Should(Equal("true"))
+					body := `{"lists":[{"id_str":"1"},{"id_str":"2"}],"next_cursor_str":"100"}`
+					if req.FormValue("cursor") != "" {
+						body = `{"lists":[{"id_str":"3"}],"next_cursor_str":"0"}`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			var ids []string
+			pager := client.ListMembershipsPager(ListMembershipsParams{ScreenName: "crowdriff", FilterToOwnedLists: true}, PagerParams{})
+			err := pager.Each(context.Background(), func(l List) error {
+				ids = append(ids, l.IDStr)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2", "3"}))
+			Ω(pager.Done()).Should(BeTrue())
+		})
+	})
+
+	Context("ListOwnerships", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.ListOwnerships(context.Background(), ListOwnershipsParams{UserID: "1"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/lists/ownerships.json"))
+					Ω(req.FormValue("user_id")).Should(Equal("1"))
+					Ω(req.FormValue("count")).Should(Equal("50"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"lists":[{"id_str":"1"}],"next_cursor_str":"0"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.ListOwnerships(context.Background(), ListOwnershipsParams{UserID: "1", Count: 50})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Lists.Lists).Should(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package twitter
+
+// Logger is the interface for receiving structured log events from a Client
+// and its streams. Each event is a message followed by alternating key/value
+// pairs. It is satisfied by *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// WithLogger returns a new shallow copy of the Client that sends log events for
+// requests, retries and streams to the provided Logger. By default, nothing is
+// logged.
+func (c *Client) WithLogger(l Logger) *Client {
+	newC := *c
+	newC.logger = l
+	newC.streamOpts.logger = l
+	return &newC
+}
+
+func (c *Client) log() Logger {
+	if c.logger == nil {
+		return nopLogger{}
+	}
+	return c.logger
+}
+
+// nopLogger implements the Logger interface by discarding every event.
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+func (nopLogger) Info(msg string, args ...interface{})  {}
+func (nopLogger) Warn(msg string, args ...interface{})  {}
+func (nopLogger) Error(msg string, args ...interface{}) {}
@@ -0,0 +1,104 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// testLogger implements the Logger interface by recording each event as its
+// level, message and arguments.
+type testLogger struct {
+	mu     sync.Mutex
+	events []logEvent
+}
+
+type logEvent struct {
+	level string
+	msg   string
+	args  []interface{}
+}
+
+func (l *testLogger) log(level, msg string, args []interface{}) {
+	l.mu.Lock()
+	l.events = append(l.events, logEvent{level, msg, args})
+	l.mu.Unlock()
+}
+
+func (l *testLogger) Debug(msg string, args ...interface{}) { l.log("debug", msg, args) }
+func (l *testLogger) Info(msg string, args ...interface{})  { l.log("info", msg, args) }
+func (l *testLogger) Warn(msg string, args ...interface{})  { l.log("warn", msg, args) }
+func (l *testLogger) Error(msg string, args ...interface{}) { l.log("error", msg, args) }
+
+func (l *testLogger) messages() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	msgs := make([]string, len(l.events))
+	for i, e := range l.events {
+		msgs[i] = e.level + " " + e.msg
+	}
+	return msgs
+}
+
+var _ = Describe("Logger", func() {
+	It("should log requests", func() {
+		l := &testLogger{}
+		hm := HTTPMock{
+			DoFn: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(strings.NewReader(`[]`)),
+				}, nil
+			},
+		}
+		client := (&Client{
+			httpClient:  &hm,
+			oauthClient: &oauth.Client{},
+			accessCreds: &oauth.Credentials{},
+		}).WithLogger(l)
+		_, err := client.UserTimeline(context.Background(), UserTimelineParams{})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(l.messages()).Should(Equal([]string{"debug twitter: request"}))
+		Ω(l.events[0].args).Should(ContainElement(200))
+	})
+
+	It("should log stream events", func() {
+		l := &testLogger{}
+		m := newStreamMock("\r\n" + `{"disconnect_message":{"code":7,"reason":"admin logout"}}` + "\r\n" + "not json\r\n")
+		m.statuses = []int{200, 503}
+		s := newStream(context.Background(), m, "GET", "", nil, nil, streamOptions{logger: l, badLineLimit: 1})
+		Ω((<-s.Messages()).Disconnect.Code).Should(Equal(7))
+		Eventually(l.messages).Should(ContainElement("warn twitter: stream HTTP error"))
+		s.Close()
+		Ω(l.messages()[:6]).Should(Equal([]string{
+			"info twitter: stream connected",
+			"debug twitter: stream keep-alive",
+			"warn twitter: stream disconnect message",
+			"error twitter: stream decode error",
+			"warn twitter: stream disconnected",
+			"info twitter: stream reconnecting",
+		}))
+	})
+
+	It("should prefer the Stream's Logger", func() {
+		clientLogger, streamLogger := &testLogger{}, &testLogger{}
+		release := make(chan struct{})
+		m := streamFuncMock(func(ctx context.Context, values url.Values) (int, string) {
+			<-release
+			return 200, "\r\n"
+		})
+		s := newStream(context.Background(), m, "GET", "", nil, nil, streamOptions{logger: clientLogger})
+		s.SetLogger(streamLogger)
+		close(release)
+		Eventually(streamLogger.messages).Should(ContainElement("debug twitter: stream keep-alive"))
+		s.Close()
+		Ω(clientLogger.messages()).ShouldNot(ContainElement("debug twitter: stream keep-alive"))
+	})
+})
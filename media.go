@@ -34,7 +34,7 @@ func (c *Client) MediaUpload(ctx context.Context, params MediaUploadParameters)
 		return nil, err
 	}
 
-	urlStr := "https://upload.twitter.com/1.1/media/upload.json"
+	urlStr := c.uploadURL("/1.1/media/upload.json")
 	return c.handleMediaUpload(ctx, "POST", urlStr, query)
 }
 
@@ -169,6 +169,37 @@ type Place struct {
 	URL         string            `json:"url"`
 }
 
+// Trend represents a trending topic.
+type Trend struct {
+	Name        string `json:"name"`
+	Query       string `json:"query"`
+	TweetVolume int    `json:"tweet_volume"`
+	URL         string `json:"url"`
+}
+
+// Trends represents the trending topics for a location at a point in time.
+type Trends struct {
+	AsOf      string          `json:"as_of"`
+	CreatedAt string          `json:"created_at"`
+	Locations []TrendLocation `json:"locations"`
+	Trends    []Trend         `json:"trends"`
+}
+
+// TrendLocation represents a location for which Twitter has trending topic
+// information, identified by its Yahoo! Where On Earth ID.
+type TrendLocation struct {
+	Country     string `json:"country"`
+	CountryCode string `json:"countryCode"`
+	Name        string `json:"name"`
+	ParentID    int64  `json:"parentid"`
+	PlaceType   struct {
+		Code int    `json:"code"`
+		Name string `json:"name"`
+	} `json:"placeType"`
+	URL   string `json:"url"`
+	WOEID int64  `json:"woeid"`
+}
+
 // Contributor represents a user who contributed to the authorship of a tweet.
 type Contributor struct {
 	ID         int64  `json:"id"`
@@ -203,6 +234,7 @@ type User struct {
 	DefaultProfile                 bool     `json:"default_profile"`
 	DefaultProfileImage            bool     `json:"default_profile_image"`
 	Description                    string   `json:"description"`
+	Email                          string   `json:"email"` // only with include_email
 	Entities                       Entities `json:"entities"`
 	FavouritesCount                int      `json:"favourites_count"`
 	FollowRequestSent              bool     `json:"follow_request_sent"`
@@ -243,6 +275,38 @@ type User struct {
 	WithheldScope                  string   `json:"withheld_scope"`
 }
 
+// Settings represents the account settings of the authenticating user.
+type Settings struct {
+	AllowContributorRequest  string          `json:"allow_contributor_request"`
+	AlwaysUseHTTPS           bool            `json:"always_use_https"`
+	DiscoverableByEmail      bool            `json:"discoverable_by_email"`
+	DiscoverableByMobile     bool            `json:"discoverable_by_mobile_phone"`
+	DisplaySensitiveMedia    bool            `json:"display_sensitive_media"`
+	GeoEnabled               bool            `json:"geo_enabled"`
+	Language                 string          `json:"language"`
+	Protected                bool            `json:"protected"`
+	ScreenName               string          `json:"screen_name"`
+	SleepTime                SleepTime       `json:"sleep_time"`
+	TimeZone                 TimeZone        `json:"time_zone"`
+	TrendLocation            []TrendLocation `json:"trend_location"`
+	UseCookiePersonalization bool            `json:"use_cookie_personalization"`
+}
+
+// SleepTime represents the hours during which the user receives no
+// notifications. The hours are null unless sleep time is enabled.
+type SleepTime struct {
+	Enabled   bool `json:"enabled"`
+	EndTime   *int `json:"end_time"`
+	StartTime *int `json:"start_time"`
+}
+
+// TimeZone represents the time zone of a user's account settings.
+type TimeZone struct {
+	Name       string `json:"name"`
+	TZInfoName string `json:"tzinfo_name"`
+	UTCOffset  int    `json:"utc_offset"`
+}
+
 // Configuration represents the configuration object received from Twitter help/configuration endpoint
 type Configuration struct {
 	CharactersReservedPerMedia int                  `json:"characters_reserved_per_media"`
@@ -293,13 +357,56 @@ type IDs struct {
 	NextCursorStr     string   `json:"next_cursor_str"`
 }
 
+// CursoredUsers represents a paginated list of users.
+type CursoredUsers struct {
+	Users             []User `json:"users"`
+	PreviousCursor    int64  `json:"previous_cursor"`
+	PreviousCursorStr string `json:"previous_cursor_str"`
+	NextCursor        int64  `json:"next_cursor"`
+	NextCursorStr     string `json:"next_cursor_str"`
+}
+
+// List represents a curated group of Twitter users.
+type List struct {
+	CreatedAt       string `json:"created_at"`
+	Description     string `json:"description"`
+	Following       bool   `json:"following"`
+	FullName        string `json:"full_name"`
+	ID              int64  `json:"id"`
+	IDStr           string `json:"id_str"`
+	MemberCount     int    `json:"member_count"`
+	Mode            string `json:"mode"`
+	Name            string `json:"name"`
+	Slug            string `json:"slug"`
+	SubscriberCount int    `json:"subscriber_count"`
+	URI             string `json:"uri"`
+	User            User   `json:"user"`
+}
+
+// CursoredLists represents a paginated list of lists.
+type CursoredLists struct {
+	Lists             []List `json:"lists"`
+	PreviousCursor    int64  `json:"previous_cursor"`
+	PreviousCursorStr string `json:"previous_cursor_str"`
+	NextCursor        int64  `json:"next_cursor"`
+	NextCursorStr     string `json:"next_cursor_str"`
+}
+
 // RelationshipTarget represents a Twitter user's relationship with a source
 type RelationshipTarget struct {
-	IDStr      string `json:"id_str"`
-	ID         int64  `json:"id"`
-	ScreenName string `json:"screen_name"`
-	Following  bool   `json:"following"`
-	FollowedBy bool   `json:"followed_by"`
+	IDStr                string `json:"id_str"`
+	ID                   int64  `json:"id"`
+	ScreenName           string `json:"screen_name"`
+	Following            bool   `json:"following"`
+	FollowedBy           bool   `json:"followed_by"`
+	FollowingReceived    bool   `json:"following_received"`
+	FollowingRequested   bool   `json:"following_requested"`
+	NotificationsEnabled bool   `json:"notifications_enabled"`
+	WantRetweets         bool   `json:"want_retweets"`
+	CanDM                bool   `json:"can_dm"`
+	Blocking             bool   `json:"blocking"`
+	Muting               bool   `json:"muting"`
+	MarkedSpam           bool   `json:"marked_spam"`
 }
 
 // Relationship represents a pair of Twitter users' relationship with each other
@@ -0,0 +1,179 @@
+package twitter
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// BlockParams represents parameters for /blocks/create.json and
+// /blocks/destroy.json Twitter endpoints
+type BlockParams struct {
+	UserID          string
+	ScreenName      string
+	ExcludeEntities bool
+	SkipStatus      bool
+}
+
+// CreateBlock calls Twitter endpoint /blocks/create.json
+func (c *Client) CreateBlock(ctx context.Context, params BlockParams) (*UserResponse, error) {
+	values := blockToQuery(params)
+	urlStr := c.apiURL("/1.1/blocks/create.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
+
+// DestroyBlock calls Twitter endpoint /blocks/destroy.json
+func (c *Client) DestroyBlock(ctx context.Context, params BlockParams) (*UserResponse, error) {
+	values := blockToQuery(params)
+	urlStr := c.apiURL("/1.1/blocks/destroy.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
+
+func blockToQuery(params BlockParams) url.Values {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	if params.ExcludeEntities {
+		values.Set("include_entities", "false")
+	}
+	if params.SkipStatus {
+		values.Set("skip_status", "true")
+	}
+	return values
+}
+
+// MuteParams represents parameters for /mutes/users/create.json and
+// /mutes/users/destroy.json Twitter endpoints
+type MuteParams struct {
+	UserID     string
+	ScreenName string
+}
+
+// CreateMute calls Twitter endpoint /mutes/users/create.json
+func (c *Client) CreateMute(ctx context.Context, params MuteParams) (*UserResponse, error) {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	urlStr := c.apiURL("/1.1/mutes/users/create.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
+
+// DestroyMute calls Twitter endpoint /mutes/users/destroy.json
+func (c *Client) DestroyMute(ctx context.Context, params MuteParams) (*UserResponse, error) {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	urlStr := c.apiURL("/1.1/mutes/users/destroy.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
+
+// ModerationIDsParams represents parameters for /blocks/ids.json and
+// /mutes/users/ids.json Twitter endpoints
+type ModerationIDsParams struct {
+	Cursor string
+}
+
+// BlockIDs calls Twitter endpoint /blocks/ids.json
+func (c *Client) BlockIDs(ctx context.Context, params ModerationIDsParams) (*IDsResponse, error) {
+	values := moderationIDsToQuery(params)
+	urlStr := c.apiURL("/1.1/blocks/ids.json")
+	return c.handleIDsResponse(ctx, "GET", urlStr, values)
+}
+
+// MuteIDs calls Twitter endpoint /mutes/users/ids.json
+func (c *Client) MuteIDs(ctx context.Context, params ModerationIDsParams) (*IDsResponse, error) {
+	values := moderationIDsToQuery(params)
+	urlStr := c.apiURL("/1.1/mutes/users/ids.json")
+	return c.handleIDsResponse(ctx, "GET", urlStr, values)
+}
+
+// BlockIDsPager returns an IDsPager over the Twitter /blocks/ids.json
+// endpoint.
+func (c *Client) BlockIDsPager(pagerParams PagerParams) *IDsPager {
+	return NewIDsPager(func(ctx context.Context, cursor string) (*IDsResponse, error) {
+		return c.BlockIDs(ctx, ModerationIDsParams{Cursor: cursor})
+	}, pagerParams)
+}
+
+// MuteIDsPager returns an IDsPager over the Twitter /mutes/users/ids.json
+// endpoint.
+func (c *Client) MuteIDsPager(pagerParams PagerParams) *IDsPager {
+	return NewIDsPager(func(ctx context.Context, cursor string) (*IDsResponse, error) {
+		return c.MuteIDs(ctx, ModerationIDsParams{Cursor: cursor})
+	}, pagerParams)
+}
+
+func moderationIDsToQuery(params ModerationIDsParams) url.Values {
+	values := url.Values{}
+	if params.Cursor != "" {
+		values.Set("cursor", params.Cursor)
+	}
+	values.Set("stringify_ids", "true")
+	return values
+}
+
+// ModerationListParams represents parameters for /blocks/list.json and
+// /mutes/users/list.json Twitter endpoints
+type ModerationListParams struct {
+	Cursor          string
+	ExcludeEntities bool
+	SkipStatus      bool
+}
+
+// BlocksList calls Twitter endpoint /blocks/list.json
+func (c *Client) BlocksList(ctx context.Context, params ModerationListParams) (*CursoredUsersResponse, error) {
+	values := moderationListToQuery(params)
+	urlStr := c.apiURL("/1.1/blocks/list.json")
+	return c.handleCursoredUsersResponse(ctx, "GET", urlStr, values)
+}
+
+// MutesList calls Twitter endpoint /mutes/users/list.json
+func (c *Client) MutesList(ctx context.Context, params ModerationListParams) (*CursoredUsersResponse, error) {
+	values := moderationListToQuery(params)
+	urlStr := c.apiURL("/1.1/mutes/users/list.json")
+	return c.handleCursoredUsersResponse(ctx, "GET", urlStr, values)
+}
+
+// BlocksListPager returns a UsersPager over the Twitter /blocks/list.json
+// endpoint.
+func (c *Client) BlocksListPager(params ModerationListParams, pagerParams PagerParams) *UsersPager {
+	return NewUsersPager(func(ctx context.Context, cursor string) (*CursoredUsersResponse, error) {
+		params.Cursor = cursor
+		return c.BlocksList(ctx, params)
+	}, pagerParams)
+}
+
+// MutesListPager returns a UsersPager over the Twitter /mutes/users/list.json
+// endpoint.
+func (c *Client) MutesListPager(params ModerationListParams, pagerParams PagerParams) *UsersPager {
+	return NewUsersPager(func(ctx context.Context, cursor string) (*CursoredUsersResponse, error) {
+		params.Cursor = cursor
+		return c.MutesList(ctx, params)
+	}, pagerParams)
+}
+
+func moderationListToQuery(params ModerationListParams) url.Values {
+	values := url.Values{}
+	if params.Cursor != "" {
+		values.Set("cursor", params.Cursor)
+	}
+	if params.ExcludeEntities {
+		values.Set("include_entities", "false")
+	}
+	if params.SkipStatus {
+		values.Set("skip_status", "true")
+	}
+	return values
+}
+
+// ReportSpamParams represents parameters for /users/report_spam.json Twitter
+// endpoint. The user is also blocked unless PerformBlock is false.
+type ReportSpamParams struct {
+	UserID       string
+	ScreenName   string
+	PerformBlock *bool
+}
+
+// ReportSpam calls Twitter endpoint /users/report_spam.json
+func (c *Client) ReportSpam(ctx context.Context, params ReportSpamParams) (*UserResponse, error) {
+	values := friendshipUserToQuery(params.UserID, params.ScreenName)
+	if params.PerformBlock != nil {
+		values.Set("perform_block", strconv.FormatBool(*params.PerformBlock))
+	}
+	urlStr := c.apiURL("/1.1/users/report_spam.json")
+	return c.handleUserResponse(ctx, "POST", urlStr, values)
+}
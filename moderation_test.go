@@ -0,0 +1,230 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Moderation", func() {
+	Context("CreateBlock", func() {
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/blocks/create.json"))
+					Ω(req.FormValue("screen_name")).Should(Equal("spammer"))
+					Ω(req.FormValue("skip_status")).Should(Equal("true"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"screen_name":"spammer"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.CreateBlock(context.Background(), BlockParams{ScreenName: "spammer", SkipStatus: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.User.ScreenName).Should(Equal("spammer"))
+		})
+
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("oops")
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.CreateBlock(context.Background(), BlockParams{ScreenName: "spammer"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+	})
+
+	Context("DestroyBlock", func() {
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/blocks/destroy.json"))
+					Ω(req.FormValue("user_id")).Should(Equal("1"))
+					Ω(req.FormValue("include_entities")).Should(Equal("false"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"1"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.DestroyBlock(context.Background(), BlockParams{UserID: "1", ExcludeEntities: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.User.IDStr).Should(Equal("1"))
+		})
+
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("oops")
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.DestroyBlock(context.Background(), BlockParams{UserID: "1"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+	})
+
+	Context("CreateMute", func() {
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/mutes/users/create.json"))
+					Ω(req.FormValue("user_id")).Should(Equal("2"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"id_str":"2"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.CreateMute(context.Background(), MuteParams{UserID: "2"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.User.IDStr).Should(Equal("2"))
+		})
+
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("oops")
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.CreateMute(context.Background(), MuteParams{UserID: "2"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+	})
+
+	Context("DestroyMute", func() {
+		It("should return successfully", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/mutes/users/destroy.json"))
+					Ω(req.FormValue("screen_name")).Should(Equal("noisy"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"screen_name":"noisy"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.DestroyMute(context.Background(), MuteParams{ScreenName: "noisy"})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.User.ScreenName).Should(Equal("noisy"))
+		})
+
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("oops")
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.DestroyMute(context.Background(), MuteParams{ScreenName: "noisy"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+	})
+
+	Context("BlockIDsPager", func() {
+		It("should walk every page", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/blocks/ids.json"))
+					body := `{"ids":["1","2"],"next_cursor_str":"100"}`
+					if req.FormValue("cursor") == "100" {
+						body = `{"ids":["3"],"next_cursor_str":"0"}`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			var ids []string
+			err := client.BlockIDsPager(PagerParams{}).Each(context.Background(), func(id string) error {
+				ids = append(ids, id)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2", "3"}))
+		})
+	})
+
+	Context("MutesListPager", func() {
+		It("should walk every page", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/mutes/users/list.json"))
+					Ω(req.FormValue("skip_status")).Should(Equal("true"))
+					body := `{"users":[{"id_str":"1"}],"next_cursor_str":"100"}`
+					if req.FormValue("cursor") == "100" {
+						body = `{"users":[{"id_str":"2"}],"next_cursor_str":"0"}`
+					}
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(body)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			var ids []string
+			err := client.MutesListPager(ModerationListParams{SkipStatus: true}, PagerParams{}).Each(context.Background(), func(u User) error {
+				ids = append(ids, u.IDStr)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(ids).Should(Equal([]string{"1", "2"}))
+		})
+	})
+
+	Context("ReportSpam", func() {
+		It("should report spam without blocking", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.Method).Should(Equal("POST"))
+					Ω(req.URL.Path).Should(Equal("/1.1/users/report_spam.json"))
+					Ω(req.FormValue("perform_block")).Should(Equal("false"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"screen_name":"spammer"}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			performBlock := false
+			_, err := client.ReportSpam(context.Background(), ReportSpamParams{ScreenName: "spammer", PerformBlock: &performBlock})
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return nil, errors.New("oops")
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.ReportSpam(context.Background(), ReportSpamParams{ScreenName: "spammer"})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+	})
+})
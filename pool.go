@@ -0,0 +1,225 @@
+package twitter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/garyburd/go-oauth/oauth"
+)
+
+// ErrNoCredentials is returned when every access token in a CredentialPool is
+// quarantined.
+var ErrNoCredentials = errors.New("no available credentials in pool")
+
+const defaultQuarantine = 15 * time.Minute
+
+// CredentialPool represents a set of AccessCredentials that a Client rotates
+// through to spread requests across rate limits. For each request, the pool
+// picks the access token with the most remaining budget for the endpoint being
+// called. Tokens that Twitter reports as invalid or expired are quarantined
+// for QuarantineDuration. A CredentialPool is safe for concurrent use.
+type CredentialPool struct {
+	// QuarantineDuration is how long an invalid or expired token is skipped.
+	// Defaults to 15 minutes.
+	QuarantineDuration time.Duration
+
+	mu      sync.Mutex
+	creds   []*pooledCredentials
+	limiter *RateLimiter
+	now     func() time.Time
+}
+
+type pooledCredentials struct {
+	creds            *oauth.Credentials
+	requests         int
+	authFailures     int
+	lastUsed         time.Time
+	quarantinedUntil time.Time
+}
+
+// CredentialStats represents the usage of a single access token in a
+// CredentialPool.
+type CredentialStats struct {
+	Token            string
+	Requests         int
+	AuthFailures     int
+	Quarantined      bool
+	QuarantinedUntil time.Time
+}
+
+// CredentialPoolStats represents the health of a CredentialPool.
+type CredentialPoolStats struct {
+	Total       int
+	Available   int
+	Quarantined int
+	Credentials []CredentialStats
+}
+
+// NewCredentialPool returns a new CredentialPool containing the provided
+// AccessCredentials. The RateLimiter is used to compare the remaining budget of
+// each token; if it is nil, a new one is created.
+func NewCredentialPool(limiter *RateLimiter, creds ...AccessCredentials) *CredentialPool {
+	if limiter == nil {
+		limiter = NewRateLimiter()
+	}
+	p := &CredentialPool{
+		limiter: limiter,
+		now:     time.Now,
+	}
+	for _, c := range creds {
+		p.Add(c)
+	}
+	return p
+}
+
+// WithCredentialPool returns a new shallow copy of the Client that draws the
+// access credentials for each request from the provided CredentialPool.
+// Requests whose context was created with WithAccessCredentials still use
+// those credentials. The Client uses the pool's RateLimiter.
+func (c *Client) WithCredentialPool(p *CredentialPool) *Client {
+	newC := *c
+	newC.pool = p
+	newC.limiter = p.limiter
+	return &newC
+}
+
+// Add adds the AccessCredentials to the pool, if they are not already in it.
+func (p *CredentialPool) Add(creds AccessCredentials) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, pc := range p.creds {
+		if pc.creds.Token == creds.Token {
+			return
+		}
+	}
+	p.creds = append(p.creds, &pooledCredentials{
+		creds: &oauth.Credentials{
+			Token:  creds.Token,
+			Secret: creds.Secret,
+		},
+	})
+}
+
+// Remove removes the access token from the pool.
+func (p *CredentialPool) Remove(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, pc := range p.creds {
+		if pc.creds.Token == token {
+			p.creds = append(p.creds[:i], p.creds[i+1:]...)
+			return
+		}
+	}
+}
+
+// Stats returns the current health of the pool.
+func (p *CredentialPool) Stats() CredentialPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	stats := CredentialPoolStats{
+		Total:       len(p.creds),
+		Credentials: make([]CredentialStats, 0, len(p.creds)),
+	}
+	for _, pc := range p.creds {
+		quarantined := now.Before(pc.quarantinedUntil)
+		if quarantined {
+			stats.Quarantined++
+		} else {
+			stats.Available++
+		}
+		stats.Credentials = append(stats.Credentials, CredentialStats{
+			Token:            pc.creds.Token,
+			Requests:         pc.requests,
+			AuthFailures:     pc.authFailures,
+			Quarantined:      quarantined,
+			QuarantinedUntil: pc.quarantinedUntil,
+		})
+	}
+	return stats
+}
+
+// pick returns the available credentials with the most remaining budget for
+// the provided resource. Credentials without a known rate limit are preferred,
+// and ties go to the least recently used.
+func (p *CredentialPool) pick(resource string) (*oauth.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	var best *pooledCredentials
+	bestRemaining := -1
+	for _, pc := range p.creds {
+		if now.Before(pc.quarantinedUntil) {
+			continue
+		}
+		remaining := math.MaxInt32
+		if rl, ok := p.limiter.RateLimit(pc.creds.Token, resource); ok {
+			remaining = rl.Remaining
+		}
+		if remaining > bestRemaining || (remaining == bestRemaining && pc.lastUsed.Before(best.lastUsed)) {
+			best, bestRemaining = pc, remaining
+		}
+	}
+	if best == nil {
+		return nil, ErrNoCredentials
+	}
+	best.requests++
+	best.lastUsed = now
+	return best.creds, nil
+}
+
+// quarantine skips the access token for the pool's QuarantineDuration.
+func (p *CredentialPool) quarantine(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	d := p.QuarantineDuration
+	if d <= 0 {
+		d = defaultQuarantine
+	}
+	for _, pc := range p.creds {
+		if pc.creds.Token == token {
+			pc.authFailures++
+			pc.quarantinedUntil = p.now().Add(d)
+			return
+		}
+	}
+}
+
+// withPooledCredentials returns a context with credentials picked from the
+// Client's pool, unless the context already carries credentials.
+func (c *Client) withPooledCredentials(ctx context.Context, resource string) (context.Context, error) {
+	if c.pool == nil || c.appOnly || ctx.Value(accCredsKey) != nil {
+		return ctx, nil
+	}
+	creds, err := c.pool.pick(resource)
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, accCredsKey, creds), nil
+}
+
+// isInvalidToken reports whether the response is a 401 with the Twitter
+// "Invalid or expired token" error code. The response body is preserved.
+func isInvalidToken(resp *http.Response) bool {
+	if resp.StatusCode != 401 {
+		return false
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return false
+	}
+	var errs Errors
+	if json.Unmarshal(b, &errs) != nil {
+		return false
+	}
+	return errs.hasCode(errCodeInvalidToken)
+}
@@ -0,0 +1,114 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var tokenRe = regexp.MustCompile(`oauth_token="([^"]*)"`)
+
+var _ = Describe("CredentialPool", func() {
+	var (
+		used   []string
+		pool   *CredentialPool
+		client *Client
+	)
+
+	BeforeEach(func() {
+		used = nil
+		reset := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+		hm := HTTPMock{
+			DoFn: func(req *http.Request) (*http.Response, error) {
+				token := tokenRe.FindStringSubmatch(req.Header.Get("Authorization"))[1]
+				used = append(used, token)
+				r := &http.Response{
+					StatusCode: 200,
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader(`[]`)),
+				}
+				switch token {
+				case "expired":
+					r.StatusCode = 401
+					r.Body = ioutil.NopCloser(strings.NewReader(`{"errors":[{"code": 89, "message": "Invalid or expired token."}]}`))
+				case "low":
+					r.Header.Set("X-Rate-Limit-Limit", "900")
+					r.Header.Set("X-Rate-Limit-Remaining", "5")
+					r.Header.Set("X-Rate-Limit-Reset", reset)
+				case "high":
+					r.Header.Set("X-Rate-Limit-Limit", "900")
+					r.Header.Set("X-Rate-Limit-Remaining", "500")
+					r.Header.Set("X-Rate-Limit-Reset", reset)
+				}
+				return r, nil
+			},
+		}
+		pool = NewCredentialPool(nil,
+			AccessCredentials{Token: "expired"},
+			AccessCredentials{Token: "low"},
+			AccessCredentials{Token: "high"},
+		)
+		client = (&Client{
+			httpClient:  &hm,
+			oauthClient: &oauth.Client{},
+			accessCreds: &oauth.Credentials{},
+		}).WithCredentialPool(pool)
+	})
+
+	It("should quarantine invalid tokens and prefer the most remaining budget", func() {
+		ctx := context.Background()
+		_, err := client.UserTimeline(ctx, UserTimelineParams{})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring("Invalid or expired token"))
+		for i := 0; i < 4; i++ {
+			_, err = client.UserTimeline(ctx, UserTimelineParams{})
+			Ω(err).ShouldNot(HaveOccurred())
+		}
+		Ω(used).Should(Equal([]string{"expired", "low", "high", "high", "high"}))
+
+		stats := pool.Stats()
+		Ω(stats.Total).Should(Equal(3))
+		Ω(stats.Available).Should(Equal(2))
+		Ω(stats.Quarantined).Should(Equal(1))
+		Ω(stats.Credentials[0].AuthFailures).Should(Equal(1))
+		Ω(stats.Credentials[2].Requests).Should(Equal(3))
+	})
+
+	It("should use credentials from the context when provided", func() {
+		ctx := WithAccessCredentials(context.Background(), AccessCredentials{Token: "explicit"})
+		_, err := client.UserTimeline(ctx, UserTimelineParams{})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(used).Should(Equal([]string{"explicit"}))
+	})
+
+	It("should return ErrNoCredentials when every token is quarantined", func() {
+		pool.Remove("low")
+		pool.Remove("high")
+		_, err := client.UserTimeline(context.Background(), UserTimelineParams{})
+		Ω(err).Should(HaveOccurred())
+		_, err = client.UserTimeline(context.Background(), UserTimelineParams{})
+		Ω(err).Should(Equal(ErrNoCredentials))
+	})
+
+	It("should return ErrNoCredentials from user endpoints when the pool is exhausted", func() {
+		pool.Remove("expired")
+		pool.Remove("low")
+		pool.Remove("high")
+		ctx := context.Background()
+		_, err := client.ShowUser(ctx, ShowUserParams{ScreenName: "crowdriff"})
+		Ω(err).Should(Equal(ErrNoCredentials))
+		_, err = client.LookupUsers(ctx, LookupUsersParams{ScreenName: []string{"crowdriff"}})
+		Ω(err).Should(Equal(ErrNoCredentials))
+		_, err = client.ShowFriendships(ctx, ShowFriendshipsParameters{SourceScreenName: "a", TargetScreenName: "b"})
+		Ω(err).Should(Equal(ErrNoCredentials))
+		Ω(used).Should(BeEmpty())
+	})
+})
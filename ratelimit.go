@@ -0,0 +1,179 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimiter records the rate limit budget of each endpoint family for each
+// set of credentials, as reported by Twitter in response headers. A Client
+// using a RateLimiter blocks requests that would exceed the budget until the
+// rate limit window resets. A RateLimiter is safe for concurrent use and may
+// be shared between Clients.
+type RateLimiter struct {
+	mu     sync.Mutex
+	limits map[rateLimitKey]*RateLimit
+	now    func() time.Time
+}
+
+type rateLimitKey struct {
+	token    string
+	resource string
+}
+
+// NewRateLimiter returns a new, empty RateLimiter.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		limits: make(map[rateLimitKey]*RateLimit),
+		now:    time.Now,
+	}
+}
+
+// WithRateLimiter returns a new shallow copy of the Client that tracks rate
+// limits using the provided RateLimiter and waits for the rate limit window to
+// reset rather than making requests that would be rate limited.
+func (c *Client) WithRateLimiter(l *RateLimiter) *Client {
+	newC := *c
+	newC.limiter = l
+	return &newC
+}
+
+// RateLimit returns the last known rate limit for the provided access token and
+// resource (such as "/statuses/user_timeline"), and whether one is known. Use
+// an empty token for application-only auth.
+func (l *RateLimiter) RateLimit(token, resource string) (RateLimit, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rl, ok := l.limits[rateLimitKey{token, resource}]
+	if !ok || l.expired(rl) {
+		return RateLimit{}, false
+	}
+	return *rl, true
+}
+
+// Set records the rate limit for the provided access token and resource.
+func (l *RateLimiter) Set(token, resource string, rl RateLimit) {
+	l.mu.Lock()
+	l.limits[rateLimitKey{token, resource}] = &rl
+	l.mu.Unlock()
+}
+
+func (l *RateLimiter) expired(rl *RateLimit) bool {
+	return !l.now().Before(time.Unix(int64(rl.Reset), 0))
+}
+
+// wait blocks until a request can be made for the provided key, reserving one
+// request from the remaining budget.
+func (l *RateLimiter) wait(ctx context.Context, key rateLimitKey) error {
+	for {
+		l.mu.Lock()
+		rl, ok := l.limits[key]
+		if !ok || l.expired(rl) {
+			l.mu.Unlock()
+			return nil
+		}
+		if rl.Remaining > 0 {
+			rl.Remaining--
+			l.mu.Unlock()
+			return nil
+		}
+		d := time.Unix(int64(rl.Reset), 0).Sub(l.now())
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d):
+		}
+	}
+}
+
+// update records the rate limit reported in a response's headers.
+func (l *RateLimiter) update(key rateLimitKey, resp *http.Response) {
+	rl := getRateLimit(resp.Header)
+	if rl.Limit <= 0 || rl.Reset <= 0 {
+		return
+	}
+	if resp.StatusCode == 429 {
+		rl.Remaining = 0
+	}
+	l.mu.Lock()
+	l.limits[key] = &rl
+	l.mu.Unlock()
+}
+
+// rateLimitKey returns the limiter key for the credentials in the provided
+// context and the requested URL.
+func (c *Client) rateLimitKey(ctx context.Context, u *url.URL) rateLimitKey {
+	var token string
+	if !c.appOnly {
+		token = c.accessCredentials(ctx).Token
+	}
+	return rateLimitKey{
+		token:    token,
+		resource: rateLimitResource(u),
+	}
+}
+
+var idSegment = regexp.MustCompile(`/[0-9]+(/|$)`)
+
+// resourceAliases maps request paths to the resource names used by Twitter for
+// endpoints that accept the ID as a query parameter.
+var resourceAliases = map[string]string{
+	"/statuses/show": "/statuses/show/:id",
+	"/users/show":    "/users/show/:id",
+}
+
+// resourcePrefixes maps request path prefixes to the resource names used by
+// Twitter for endpoints whose path ends with a non-numeric ID.
+var resourcePrefixes = map[string]string{
+	"/geo/id/": "/geo/id/:place_id",
+}
+
+// rateLimitResource returns the Twitter rate limit resource name for the
+// provided URL, for example "/statuses/retweets/:id".
+func rateLimitResource(u *url.URL) string {
+	p := strings.TrimPrefix(u.Path, "/1.1")
+	p = strings.TrimSuffix(p, ".json")
+	for prefix, resource := range resourcePrefixes {
+		if strings.HasPrefix(p, prefix) {
+			return resource
+		}
+	}
+	p = idSegment.ReplaceAllString(p, "/:id$1")
+	if alias, ok := resourceAliases[p]; ok {
+		return alias
+	}
+	return p
+}
+
+// SeedRateLimits calls the Twitter /application/rate_limit_status.json endpoint
+// and records every returned rate limit in the Client's RateLimiter for the
+// credentials in the provided context. It returns an error without making the
+// request if the Client has no RateLimiter.
+func (c *Client) SeedRateLimits(ctx context.Context, resources []string) error {
+	if c.limiter == nil {
+		return errors.New("client has no rate limiter")
+	}
+	res, err := c.RateLimitStatus(ctx, resources)
+	if err != nil {
+		return err
+	}
+
+	var token string
+	if !c.appOnly {
+		token = c.accessCredentials(ctx).Token
+	}
+	for _, family := range res.RateLimitsRes.All {
+		for resource, rl := range family {
+			c.limiter.Set(token, resource, rl)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RateLimiter", func() {
+	Context("rateLimitResource", func() {
+		It("should return the Twitter resource name", func() {
+			for path, resource := range map[string]string{
+				"/1.1/statuses/user_timeline.json":  "/statuses/user_timeline",
+				"/1.1/statuses/retweets/12345.json": "/statuses/retweets/:id",
+				"/1.1/statuses/show.json":           "/statuses/show/:id",
+				"/1.1/users/show.json":              "/users/show/:id",
+				"/1.1/geo/id/df51dec6f4ee2b2c.json": "/geo/id/:place_id",
+				"/1.1/geo/id/12345.json":            "/geo/id/:place_id",
+			} {
+				Ω(rateLimitResource(&url.URL{Path: path})).Should(Equal(resource))
+			}
+		})
+	})
+
+	Context("WithRateLimiter", func() {
+		var (
+			limiter *RateLimiter
+			client  *Client
+			header  http.Header
+		)
+
+		BeforeEach(func() {
+			header = http.Header{}
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 200,
+						Header:     header,
+						Body:       ioutil.NopCloser(strings.NewReader(`[]`)),
+					}, nil
+				},
+			}
+			limiter = NewRateLimiter()
+			client = (&Client{
+				httpClient:  &hm,
+				oauthClient: &oauth.Client{},
+				accessCreds: &oauth.Credentials{Token: "token"},
+			}).WithRateLimiter(limiter)
+		})
+
+		It("should record rate limits from response headers", func() {
+			reset := time.Now().Add(time.Minute).Unix()
+			header.Set("X-Rate-Limit-Limit", "900")
+			header.Set("X-Rate-Limit-Remaining", "899")
+			header.Set("X-Rate-Limit-Reset", strconv.FormatInt(reset, 10))
+
+			_, err := client.UserTimeline(context.Background(), UserTimelineParams{})
+			Ω(err).ShouldNot(HaveOccurred())
+			rl, ok := limiter.RateLimit("token", "/statuses/user_timeline")
+			Ω(ok).Should(BeTrue())
+			Ω(rl).Should(Equal(RateLimit{Limit: 900, Remaining: 899, Reset: int(reset)}))
+
+			ctx := WithAccessCredentials(context.Background(), AccessCredentials{Token: "other"})
+			_, ok = limiter.RateLimit("other", "/statuses/user_timeline")
+			Ω(ok).Should(BeFalse())
+			_, err = client.UserTimeline(ctx, UserTimelineParams{})
+			Ω(err).ShouldNot(HaveOccurred())
+			_, ok = limiter.RateLimit("other", "/statuses/user_timeline")
+			Ω(ok).Should(BeTrue())
+		})
+
+		It("should block until the window resets when no requests remain", func() {
+			limiter.Set("token", "/statuses/user_timeline", RateLimit{
+				Limit:     900,
+				Remaining: 0,
+				Reset:     int(time.Now().Add(time.Hour).Unix()),
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err := client.do(ctx, "GET", "https://api.twitter.com/1.1/statuses/user_timeline.json", nil)
+			Ω(err).Should(Equal(context.DeadlineExceeded))
+
+			_, err = client.do(context.Background(), "GET", "https://api.twitter.com/1.1/statuses/home_timeline.json", nil)
+			Ω(err).ShouldNot(HaveOccurred())
+		})
+	})
+
+	Context("SeedRateLimits", func() {
+		It("should record every returned rate limit", func() {
+			reset := time.Now().Add(time.Minute).Unix()
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.FormValue("resources")).Should(Equal("statuses"))
+					return &http.Response{
+						StatusCode: 200,
+						Body: ioutil.NopCloser(strings.NewReader(`{"resources":{"statuses":{
+							"/statuses/user_timeline":{"limit":900,"remaining":10,"reset":` + strconv.FormatInt(reset, 10) + `}}}}`)),
+					}, nil
+				},
+			}
+			limiter := NewRateLimiter()
+			client := (&Client{
+				httpClient:  &hm,
+				oauthClient: &oauth.Client{},
+				accessCreds: &oauth.Credentials{Token: "token"},
+			}).WithRateLimiter(limiter)
+
+			err := client.SeedRateLimits(context.Background(), []string{"statuses"})
+			Ω(err).ShouldNot(HaveOccurred())
+			rl, ok := limiter.RateLimit("token", "/statuses/user_timeline")
+			Ω(ok).Should(BeTrue())
+			Ω(rl.Remaining).Should(Equal(10))
+		})
+
+		It("should return an error without a request when there is no RateLimiter", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Fail("unexpected request")
+					return nil, nil
+				},
+			}
+			client := &Client{
+				httpClient:  &hm,
+				oauthClient: &oauth.Client{},
+				accessCreds: &oauth.Credentials{},
+			}
+			err := client.SeedRateLimits(context.Background(), nil)
+			Ω(err).Should(MatchError("client has no rate limiter"))
+		})
+	})
+})
@@ -0,0 +1,203 @@
+package twitter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultInitialDelay = time.Second
+	defaultMaxDelay     = 30 * time.Second
+)
+
+// RetryErrFn represents a function that is called when a REST request fails
+// and will be retried. If the RetryErrFn returns a non-nil error, the request
+// is abandoned and the error is returned to the caller.
+type RetryErrFn func(Backoff, error) error
+
+// RetryPolicy represents how a Client retries REST requests that fail with a
+// network error, a 5xx status, or a 429 (rate limited) status. GET and HEAD
+// requests are always retried; other methods are only retried when RetryPOST
+// is set, as they may not be idempotent.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries for a single request.
+	// Defaults to 3.
+	MaxRetries int
+	// InitialDelay is the base delay before the first retry. It is doubled
+	// (with jitter) for each subsequent retry. Defaults to 1 second.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between retries. Defaults to 30 seconds. It
+	// does not apply when waiting for a rate limit window to reset.
+	MaxDelay time.Duration
+	// RetryPOST allows requests with a body to be buffered and retried.
+	RetryPOST bool
+	// ErrFn is called (if it exists) with the current Backoff and the
+	// specific error before each retry.
+	ErrFn RetryErrFn
+}
+
+// WithRetryPolicy returns a new shallow copy of the Client that retries REST
+// requests according to the provided RetryPolicy. Stream connections are never
+// retried by the RetryPolicy, as streams reconnect according to their own
+// BackoffPolicy.
+func (c *Client) WithRetryPolicy(policy RetryPolicy) *Client {
+	newC := *c
+	newC.retry = &policy
+	return &newC
+}
+
+func (p *RetryPolicy) canRetry(method string) bool {
+	switch method {
+	case "GET", "HEAD":
+		return true
+	default:
+		return p.RetryPOST
+	}
+}
+
+func (p *RetryPolicy) maxRetries() int {
+	if p.MaxRetries <= 0 {
+		return defaultMaxRetries
+	}
+	return p.MaxRetries
+}
+
+// delay returns the jittered exponential delay before the provided retry.
+func (p *RetryPolicy) delay(retries int) time.Duration {
+	d, max := p.InitialDelay, p.MaxDelay
+	if d <= 0 {
+		d = defaultInitialDelay
+	}
+	if max <= 0 {
+		max = defaultMaxDelay
+	}
+	for i := 0; i < retries && d < max; i++ {
+		d *= 2
+	}
+	if d > max {
+		d = max
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func (p *RetryPolicy) notifyError(boff Backoff, err error) error {
+	if p.ErrFn == nil {
+		return nil
+	}
+	return p.ErrFn(boff, err)
+}
+
+// retryBackoff implements the Backoff interface for REST retries.
+type retryBackoff struct {
+	next    time.Duration
+	waited  time.Duration
+	retries int
+}
+
+func (b *retryBackoff) NextWait() time.Duration {
+	return b.next
+}
+
+func (b *retryBackoff) Waited() time.Duration {
+	return b.waited
+}
+
+func (b *retryBackoff) Retries() int {
+	return b.retries
+}
+
+// executeRetry makes the request described by execute, retrying transient
+// failures according to the Client's RetryPolicy.
+func (c *Client) executeRetry(ctx context.Context, method, urlStr, contentType string, body io.Reader, values url.Values) (*http.Response, error) {
+	// Buffer the body so that it can be sent again.
+	var b []byte
+	if body != nil {
+		var err error
+		b, err = ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	boff := &retryBackoff{}
+	for {
+		var r io.Reader
+		if b != nil {
+			r = bytes.NewReader(b)
+		}
+		resp, err := c.executeOnce(ctx, method, urlStr, contentType, r, values)
+		if boff.retries >= c.retry.maxRetries() || ctx.Err() != nil {
+			return resp, err
+		}
+
+		wait, ok := c.retry.retryWait(resp, err, boff.retries)
+		if !ok {
+			return resp, err
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(wait).After(deadline) {
+			return resp, err
+		}
+		if err == nil {
+			err = checkResponse(resp)
+			resp.Body.Close()
+		}
+
+		boff.next = wait
+		c.log().Info("twitter: retrying request", "method", method, "url", urlStr,
+			"wait", wait, "retries", boff.retries, "error", err)
+		if err = c.retry.notifyError(boff, err); err != nil {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+		boff.waited += wait
+		boff.retries++
+	}
+}
+
+// retryWait returns how long to wait before retrying the request that produced
+// the provided response or error, and whether it should be retried at all.
+func (p *RetryPolicy) retryWait(resp *http.Response, err error, retries int) (time.Duration, bool) {
+	if err != nil {
+		if !isTransportError(err) {
+			return 0, false
+		}
+		return p.delay(retries), true
+	}
+	switch resp.StatusCode {
+	case 429:
+		// Wait until the rate limit window resets, if Twitter told us when.
+		reset := getRateLimit(resp.Header).Reset
+		if d := time.Until(time.Unix(int64(reset), 0)); reset > 0 && d > 0 {
+			return d, true
+		}
+		return p.delay(retries), true
+	case 500, 502, 503, 504:
+		return p.delay(retries), true
+	default:
+		return 0, false
+	}
+}
+
+// isTransportError reports whether err is a network failure from sending the
+// request. Context errors, and errors returned before the request was sent
+// (such as ErrNoCredentials), are not transport errors and are not retried.
+func isTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,155 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Retry", func() {
+	var (
+		attempts int
+		statuses []int
+		header   http.Header
+		client   *Client
+	)
+
+	BeforeEach(func() {
+		attempts = 0
+		header = http.Header{}
+		hm := HTTPMock{
+			DoFn: func(req *http.Request) (*http.Response, error) {
+				status := statuses[attempts]
+				attempts++
+				if status == 0 {
+					return nil, &url.Error{Op: req.Method, URL: req.URL.String(), Err: errors.New("connection reset")}
+				}
+				if req.Method == "POST" {
+					body, _ := ioutil.ReadAll(req.Body)
+					Ω(string(body)).Should(Equal("status=hello"))
+				}
+				return &http.Response{
+					StatusCode: status,
+					Header:     header,
+					Body:       ioutil.NopCloser(strings.NewReader(`{"errors":[{"code": 131, "message": "oops"}]}`)),
+				}, nil
+			},
+		}
+		client = &Client{
+			httpClient:  &hm,
+			oauthClient: &oauth.Client{},
+			accessCreds: &oauth.Credentials{},
+		}
+	})
+
+	It("should retry GET requests on 5xx and network errors", func() {
+		statuses = []int{503, 0, 200}
+		var retries []int
+		c := client.WithRetryPolicy(RetryPolicy{
+			InitialDelay: time.Millisecond,
+			ErrFn: func(boff Backoff, err error) error {
+				retries = append(retries, boff.Retries())
+				return nil
+			},
+		})
+		resp, err := c.do(context.Background(), "GET", "https://api.twitter.com/1.1/statuses/user_timeline.json", nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(resp.StatusCode).Should(Equal(200))
+		Ω(attempts).Should(Equal(3))
+		Ω(retries).Should(Equal([]int{0, 1}))
+	})
+
+	It("should return the last response after MaxRetries", func() {
+		statuses = []int{500, 500, 500}
+		c := client.WithRetryPolicy(RetryPolicy{
+			MaxRetries:   2,
+			InitialDelay: time.Millisecond,
+		})
+		_, err := c.UserTimeline(context.Background(), UserTimelineParams{})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring("oops"))
+		Ω(attempts).Should(Equal(3))
+	})
+
+	It("should not retry POST requests unless allowed", func() {
+		statuses = []int{503, 200}
+		c := client.WithRetryPolicy(RetryPolicy{InitialDelay: time.Millisecond})
+		_, err := c.UpdateTweet(context.Background(), UpdateTweetParams{Status: "hello"})
+		Ω(err).Should(HaveOccurred())
+		Ω(attempts).Should(Equal(1))
+
+		attempts = 0
+		c = client.WithRetryPolicy(RetryPolicy{InitialDelay: time.Millisecond, RetryPOST: true})
+		resp, err := c.do(context.Background(), "POST", "https://api.twitter.com/1.1/statuses/update.json", map[string][]string{"status": {"hello"}})
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(resp.StatusCode).Should(Equal(200))
+		Ω(attempts).Should(Equal(2))
+	})
+
+	It("should not wait for a rate limit reset past the context deadline", func() {
+		statuses = []int{429, 200}
+		header.Set("X-Rate-Limit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		c := client.WithRetryPolicy(RetryPolicy{})
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/statuses/user_timeline.json", nil)
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(resp.StatusCode).Should(Equal(429))
+		Ω(attempts).Should(Equal(1))
+	})
+
+	It("should abandon the request when ErrFn returns an error", func() {
+		statuses = []int{502, 200}
+		c := client.WithRetryPolicy(RetryPolicy{
+			ErrFn: func(boff Backoff, err error) error {
+				return errors.New("giving up")
+			},
+		})
+		_, err := c.do(context.Background(), "GET", "https://api.twitter.com/1.1/statuses/user_timeline.json", nil)
+		Ω(err).Should(MatchError("giving up"))
+		Ω(attempts).Should(Equal(1))
+	})
+
+	It("should not retry errors returned before the request is sent", func() {
+		notified := 0
+		c := client.WithCredentialPool(NewCredentialPool(nil)).WithRetryPolicy(RetryPolicy{
+			InitialDelay: time.Millisecond,
+			ErrFn: func(boff Backoff, err error) error {
+				notified++
+				return nil
+			},
+		})
+		_, err := c.UserTimeline(context.Background(), UserTimelineParams{})
+		Ω(err).Should(Equal(ErrNoCredentials))
+		Ω(attempts).Should(Equal(0))
+		Ω(notified).Should(Equal(0))
+	})
+
+	It("should not retry context errors", func() {
+		Ω(isTransportError(context.Canceled)).Should(BeFalse())
+		Ω(isTransportError(&url.Error{Op: "Get", Err: context.DeadlineExceeded})).Should(BeFalse())
+		Ω(isTransportError(&url.Error{Op: "Get", Err: errors.New("connection reset")})).Should(BeTrue())
+	})
+
+	It("should leave stream reconnects to the stream", func() {
+		statuses = []int{503, 503, 503, 503}
+		c := client.WithRetryPolicy(RetryPolicy{InitialDelay: time.Millisecond})
+		var streamErr error
+		s := c.StartSampleStream(context.Background(), StreamSampleParams{}, func(boff Backoff, err error) error {
+			streamErr = err
+			return err
+		})
+		Eventually(s.Done()).Should(BeClosed())
+		Ω(attempts).Should(Equal(1))
+		Ω(streamErr).Should(HaveOccurred())
+	})
+})
@@ -31,7 +31,7 @@ type searchRes struct {
 // SearchTweets calls the Twitter /search/tweets.json endpoint.
 func (c *Client) SearchTweets(ctx context.Context, params SearchTweetsParams) (*TweetsResponse, error) {
 	values := searchTweetsToQuery(params)
-	resp, err := c.do(ctx, "GET", "https://api.twitter.com/1.1/search/tweets.json", values)
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/search/tweets.json"), values)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,267 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/garyburd/go-oauth/oauth"
+)
+
+// TemporaryCredentials represents the OAuth request token and secret used
+// while a user authorizes the application.
+type TemporaryCredentials struct {
+	Token             string
+	Secret            string
+	CallbackConfirmed bool
+}
+
+// AccessTokenResponse represents a response from Twitter after exchanging a
+// verifier for access credentials.
+type AccessTokenResponse struct {
+	AccessCredentials AccessCredentials
+	UserID            string
+	ScreenName        string
+}
+
+// AuthorizeParams represents the query parameters for an /oauth/authorize or
+// /oauth/authenticate URL.
+type AuthorizeParams struct {
+	ForceLogin bool
+	ScreenName string
+}
+
+// RequestTemporaryCredentials calls the Twitter /oauth/request_token endpoint
+// with the provided callback URL. Use "oob" as the callback URL for PIN based
+// authorization.
+func (c *Client) RequestTemporaryCredentials(ctx context.Context, callbackURL string) (*TemporaryCredentials, error) {
+	values := url.Values{}
+	values.Set("oauth_callback", callbackURL)
+	res, err := c.doTokenRequest(ctx, c.apiURL("/oauth/request_token"), nil, values)
+	if err != nil {
+		return nil, err
+	}
+	return &TemporaryCredentials{
+		Token:             res.Get("oauth_token"),
+		Secret:            res.Get("oauth_token_secret"),
+		CallbackConfirmed: res.Get("oauth_callback_confirmed") == "true",
+	}, nil
+}
+
+// AuthorizeURL returns the Twitter /oauth/authorize URL that the user should be
+// redirected to in order to authorize the provided temporary credentials.
+func (c *Client) AuthorizeURL(tempCreds TemporaryCredentials, params AuthorizeParams) string {
+	return c.apiURL("/oauth/authorize?") + authorizeToQuery(tempCreds, params).Encode()
+}
+
+// AuthenticateURL returns the Twitter /oauth/authenticate URL used for "Sign in
+// with Twitter". Users that have already authorized the application are
+// redirected back immediately.
+func (c *Client) AuthenticateURL(tempCreds TemporaryCredentials, params AuthorizeParams) string {
+	return c.apiURL("/oauth/authenticate?") + authorizeToQuery(tempCreds, params).Encode()
+}
+
+func authorizeToQuery(tempCreds TemporaryCredentials, params AuthorizeParams) url.Values {
+	values := url.Values{}
+	values.Set("oauth_token", tempCreds.Token)
+	if params.ForceLogin {
+		values.Set("force_login", "true")
+	}
+	if params.ScreenName != "" {
+		values.Set("screen_name", params.ScreenName)
+	}
+	return values
+}
+
+// RequestAccessCredentials calls the Twitter /oauth/access_token endpoint to
+// exchange the temporary credentials and verifier for AccessCredentials.
+func (c *Client) RequestAccessCredentials(ctx context.Context, tempCreds TemporaryCredentials, verifier string) (*AccessTokenResponse, error) {
+	values := url.Values{}
+	values.Set("oauth_verifier", verifier)
+	creds := &oauth.Credentials{
+		Token:  tempCreds.Token,
+		Secret: tempCreds.Secret,
+	}
+	res, err := c.doTokenRequest(ctx, c.apiURL("/oauth/access_token"), creds, values)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessTokenResponse{
+		AccessCredentials: AccessCredentials{
+			Token:  res.Get("oauth_token"),
+			Secret: res.Get("oauth_token_secret"),
+		},
+		UserID:     res.Get("user_id"),
+		ScreenName: res.Get("screen_name"),
+	}, nil
+}
+
+// doTokenRequest makes a signed POST request to one of the OAuth token
+// endpoints and parses the form encoded response.
+func (c *Client) doTokenRequest(ctx context.Context, urlStr string, creds *oauth.Credentials, values url.Values) (url.Values, error) {
+	req, err := http.NewRequest("POST", urlStr, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	err = c.oauthClient.SetAuthorizationHeader(req.Header, creds, req.Method, req.URL, values)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	res, err := url.ParseQuery(string(b))
+	if err != nil {
+		return nil, err
+	}
+	if res.Get("oauth_token") == "" || res.Get("oauth_token_secret") == "" {
+		return nil, errors.New("missing oauth token in response")
+	}
+	return res, nil
+}
+
+// tempCredsTTL is how long temporary credentials are kept by a SignIn while
+// waiting for the user to return to the callback.
+const tempCredsTTL = 15 * time.Minute
+
+// SignIn drives the three-legged OAuth flow over HTTP. The handler returned by
+// LoginHandler redirects the user to Twitter, and the handler returned by
+// CallbackHandler completes the flow when Twitter redirects the user back.
+type SignIn struct {
+	// Client is used to request the temporary and access credentials.
+	Client *Client
+	// CallbackURL is the absolute URL that CallbackHandler is served on.
+	CallbackURL string
+	// Authenticate uses the /oauth/authenticate URL instead of
+	// /oauth/authorize.
+	Authenticate bool
+	// AuthorizeParams are added to the authorize/authenticate URL.
+	AuthorizeParams AuthorizeParams
+	// OnSuccess is called with the access credentials once the flow is
+	// complete. It is responsible for writing the response and is required;
+	// without it, CallbackHandler responds with a 500 status.
+	OnSuccess func(http.ResponseWriter, *http.Request, *AccessTokenResponse)
+	// OnError is called if the flow fails. If nil, a 500 or 400 status is
+	// written.
+	OnError func(http.ResponseWriter, *http.Request, error)
+
+	mu      sync.Mutex
+	pending map[string]pendingCreds
+}
+
+type pendingCreds struct {
+	creds   TemporaryCredentials
+	expires time.Time
+}
+
+// LoginHandler returns an http.Handler that requests temporary credentials and
+// redirects the user to Twitter.
+func (s *SignIn) LoginHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tempCreds, err := s.Client.RequestTemporaryCredentials(r.Context(), s.CallbackURL)
+		if err != nil {
+			s.fail(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		s.store(*tempCreds)
+
+		var urlStr string
+		if s.Authenticate {
+			urlStr = s.Client.AuthenticateURL(*tempCreds, s.AuthorizeParams)
+		} else {
+			urlStr = s.Client.AuthorizeURL(*tempCreds, s.AuthorizeParams)
+		}
+		http.Redirect(w, r, urlStr, http.StatusFound)
+	})
+}
+
+// CallbackHandler returns an http.Handler that exchanges the oauth_verifier
+// returned by Twitter for access credentials and calls OnSuccess.
+func (s *SignIn) CallbackHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.OnSuccess == nil {
+			s.fail(w, r, http.StatusInternalServerError, errors.New("SignIn OnSuccess is required"))
+			return
+		}
+		query := r.URL.Query()
+		if denied := query.Get("denied"); denied != "" {
+			// Twitter sets denied to the temporary token, which will never
+			// be exchanged.
+			s.load(denied)
+			s.fail(w, r, http.StatusBadRequest, errors.New("authorization denied"))
+			return
+		}
+		tempCreds, ok := s.load(query.Get("oauth_token"))
+		if !ok {
+			s.fail(w, r, http.StatusBadRequest, errors.New("unknown or expired oauth token"))
+			return
+		}
+		res, err := s.Client.RequestAccessCredentials(r.Context(), tempCreds, query.Get("oauth_verifier"))
+		if err != nil {
+			s.fail(w, r, http.StatusInternalServerError, err)
+			return
+		}
+		s.OnSuccess(w, r, res)
+	})
+}
+
+func (s *SignIn) fail(w http.ResponseWriter, r *http.Request, code int, err error) {
+	if s.OnError != nil {
+		s.OnError(w, r, err)
+		return
+	}
+	http.Error(w, err.Error(), code)
+}
+
+// store saves the temporary credentials until the user returns, dropping any
+// that have expired.
+func (s *SignIn) store(creds TemporaryCredentials) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if s.pending == nil {
+		s.pending = make(map[string]pendingCreds)
+	}
+	for token, p := range s.pending {
+		if now.After(p.expires) {
+			delete(s.pending, token)
+		}
+	}
+	s.pending[creds.Token] = pendingCreds{
+		creds:   creds,
+		expires: now.Add(tempCredsTTL),
+	}
+}
+
+// load removes and returns the temporary credentials for the provided token.
+func (s *SignIn) load(token string) (TemporaryCredentials, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.pending[token]
+	if !ok {
+		return TemporaryCredentials{}, false
+	}
+	delete(s.pending, token)
+	if time.Now().After(p.expires) {
+		return TemporaryCredentials{}, false
+	}
+	return p.creds, true
+}
@@ -0,0 +1,135 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SignIn", func() {
+	hm := HTTPMock{
+		DoFn: func(req *http.Request) (*http.Response, error) {
+			r := &http.Response{
+				StatusCode: 200,
+			}
+			switch req.URL.Path {
+			case "/oauth/request_token":
+				Ω(req.FormValue("oauth_callback")).Should(Equal("https://example.com/callback"))
+				r.Body = ioutil.NopCloser(strings.NewReader("oauth_token=temp&oauth_token_secret=tempsecret&oauth_callback_confirmed=true"))
+			case "/oauth/access_token":
+				Ω(req.FormValue("oauth_verifier")).Should(Equal("verifier"))
+				r.Body = ioutil.NopCloser(strings.NewReader("oauth_token=token&oauth_token_secret=secret&user_id=12345&screen_name=someone"))
+			default:
+				r.StatusCode = 400
+				r.Body = ioutil.NopCloser(strings.NewReader(`{"errors":[{"code": 400, "message": "oops"}]}`))
+			}
+			return r, nil
+		},
+	}
+
+	client := &Client{
+		httpClient:  &hm,
+		oauthClient: &oauth.Client{},
+		accessCreds: &oauth.Credentials{},
+	}
+
+	Context("RequestTemporaryCredentials", func() {
+		It("should return the temporary credentials", func() {
+			tempCreds, err := client.RequestTemporaryCredentials(context.Background(), "https://example.com/callback")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(*tempCreds).Should(Equal(TemporaryCredentials{
+				Token:             "temp",
+				Secret:            "tempsecret",
+				CallbackConfirmed: true,
+			}))
+		})
+	})
+
+	Context("AuthenticateURL", func() {
+		It("should return the authenticate URL", func() {
+			urlStr := client.AuthenticateURL(TemporaryCredentials{Token: "temp"}, AuthorizeParams{ForceLogin: true})
+			Ω(urlStr).Should(Equal("https://api.twitter.com/oauth/authenticate?force_login=true&oauth_token=temp"))
+		})
+	})
+
+	Context("RequestAccessCredentials", func() {
+		It("should return the access credentials and user", func() {
+			res, err := client.RequestAccessCredentials(context.Background(), TemporaryCredentials{Token: "temp"}, "verifier")
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.AccessCredentials).Should(Equal(AccessCredentials{Token: "token", Secret: "secret"}))
+			Ω(res.UserID).Should(Equal("12345"))
+			Ω(res.ScreenName).Should(Equal("someone"))
+		})
+	})
+
+	Context("Handlers", func() {
+		It("should redirect and complete the flow", func() {
+			var success *AccessTokenResponse
+			s := SignIn{
+				Client:      client,
+				CallbackURL: "https://example.com/callback",
+				OnSuccess: func(w http.ResponseWriter, r *http.Request, res *AccessTokenResponse) {
+					success = res
+				},
+			}
+
+			w := httptest.NewRecorder()
+			s.LoginHandler().ServeHTTP(w, httptest.NewRequest("GET", "/login", nil))
+			Ω(w.Code).Should(Equal(http.StatusFound))
+			Ω(w.Header().Get("Location")).Should(Equal("https://api.twitter.com/oauth/authorize?oauth_token=temp"))
+
+			w = httptest.NewRecorder()
+			s.CallbackHandler().ServeHTTP(w, httptest.NewRequest("GET", "/callback?oauth_token=temp&oauth_verifier=verifier", nil))
+			Ω(success).ShouldNot(BeNil())
+			Ω(success.ScreenName).Should(Equal("someone"))
+
+			w = httptest.NewRecorder()
+			s.CallbackHandler().ServeHTTP(w, httptest.NewRequest("GET", "/callback?oauth_token=temp&oauth_verifier=verifier", nil))
+			Ω(w.Code).Should(Equal(http.StatusBadRequest))
+		})
+
+		It("should drop the temporary credentials when authorization is denied", func() {
+			s := SignIn{
+				Client:      client,
+				CallbackURL: "https://example.com/callback",
+				OnSuccess:   func(w http.ResponseWriter, r *http.Request, res *AccessTokenResponse) {},
+			}
+			s.LoginHandler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/login", nil))
+			Ω(s.pending).Should(HaveKey("temp"))
+
+			w := httptest.NewRecorder()
+			s.CallbackHandler().ServeHTTP(w, httptest.NewRequest("GET", "/callback?denied=temp", nil))
+			Ω(w.Code).Should(Equal(http.StatusBadRequest))
+			Ω(s.pending).ShouldNot(HaveKey("temp"))
+		})
+
+		It("should fail without exchanging the token when OnSuccess is nil", func() {
+			exchanged := false
+			s := SignIn{
+				Client: &Client{
+					httpClient: &HTTPMock{
+						DoFn: func(req *http.Request) (*http.Response, error) {
+							exchanged = true
+							return hm.Do(req)
+						},
+					},
+					oauthClient: &oauth.Client{},
+					accessCreds: &oauth.Credentials{},
+				},
+				CallbackURL: "https://example.com/callback",
+			}
+			s.store(TemporaryCredentials{Token: "temp"})
+
+			w := httptest.NewRecorder()
+			s.CallbackHandler().ServeHTTP(w, httptest.NewRequest("GET", "/callback?oauth_token=temp&oauth_verifier=verifier", nil))
+			Ω(w.Code).Should(Equal(http.StatusInternalServerError))
+			Ω(exchanged).Should(BeFalse())
+		})
+	})
+})
@@ -22,7 +22,7 @@ type MentionsTimelineParams struct {
 // MentionsTimeline calls the Twitter /statuses/mentions_timeline.json endpoint.
 func (c *Client) MentionsTimeline(ctx context.Context, params MentionsTimelineParams) (*TweetsResponse, error) {
 	values := mentionsTimelineToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/mentions_timeline.json"
+	urlStr := c.apiURL("/1.1/statuses/mentions_timeline.json")
 	return c.handleTweetsResponse(ctx, "GET", urlStr, values)
 }
 
@@ -66,7 +66,7 @@ type UserTimelineParams struct {
 // UserTimeline calls the Twitter /statuses/user_timeline.json endpoint.
 func (c *Client) UserTimeline(ctx context.Context, params UserTimelineParams) (*TweetsResponse, error) {
 	values := userTimelineToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/user_timeline.json"
+	urlStr := c.apiURL("/1.1/statuses/user_timeline.json")
 	return c.handleTweetsResponse(ctx, "GET", urlStr, values)
 }
 
@@ -117,7 +117,7 @@ type HomeTimelineParams struct {
 // HomeTimeline calls the Twitter /statuses/home_timeline.json endpoint.
 func (c *Client) HomeTimeline(ctx context.Context, params HomeTimelineParams) (*TweetsResponse, error) {
 	values := homeTimelineToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/home_timeline.json"
+	urlStr := c.apiURL("/1.1/statuses/home_timeline.json")
 	return c.handleTweetsResponse(ctx, "GET", urlStr, values)
 }
 
@@ -161,7 +161,7 @@ type RetweetsOfMeParams struct {
 // RetweetsOfMe calls the Twitter /statuses/retweets_of_me.json endpoint.
 func (c *Client) RetweetsOfMe(ctx context.Context, params RetweetsOfMeParams) (*TweetsResponse, error) {
 	values := retweetsOfMeToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/retweets_of_me.json"
+	urlStr := c.apiURL("/1.1/statuses/retweets_of_me.json")
 	return c.handleTweetsResponse(ctx, "GET", urlStr, values)
 }
 
@@ -199,7 +199,7 @@ type RetweetsOfTweetParams struct {
 // RetweetsOfTweet calls the Twitter /statuses/retweets/:id.json endpoint.
 func (c *Client) RetweetsOfTweet(ctx context.Context, params RetweetsOfTweetParams) (*TweetsResponse, error) {
 	values := retweetsOfTweetToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/retweets/" + params.ID + ".json"
+	urlStr := c.apiURL("/1.1/statuses/retweets/") + params.ID + ".json"
 	return c.handleTweetsResponse(ctx, "GET", urlStr, values)
 }
 
@@ -227,7 +227,7 @@ type ShowTweetParams struct {
 // ShowTweet calls the Twitter /statuses/show/:id.json endpoint.
 func (c *Client) ShowTweet(ctx context.Context, params ShowTweetParams) (*TweetResponse, error) {
 	values := showTweetToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/show.json"
+	urlStr := c.apiURL("/1.1/statuses/show.json")
 	return c.handleTweetResponse(ctx, "GET", urlStr, values)
 }
 
@@ -259,7 +259,7 @@ type DestroyTweetParams struct {
 // DestroyTweet calls the Twitter /statuses/destroy/:id.json endpoint.
 func (c *Client) DestroyTweet(ctx context.Context, params DestroyTweetParams) (*TweetResponse, error) {
 	values := destroyTweetToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/destroy/" + params.ID + ".json"
+	urlStr := c.apiURL("/1.1/statuses/destroy/") + params.ID + ".json"
 	return c.handleTweetResponse(ctx, "POST", urlStr, values)
 }
 
@@ -288,7 +288,7 @@ type UpdateTweetParams struct {
 // UpdateTweet calls the Twitter /statuses/update.json endpoint.
 func (c *Client) UpdateTweet(ctx context.Context, params UpdateTweetParams) (*TweetResponse, error) {
 	values := updateTweetToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/update.json"
+	urlStr := c.apiURL("/1.1/statuses/update.json")
 	return c.handleTweetResponse(ctx, "POST", urlStr, values)
 }
 
@@ -330,7 +330,7 @@ type RetweetParams struct {
 // Retweet calls the Twitter /statuses/retweet/:id.json endpoint.
 func (c *Client) Retweet(ctx context.Context, params RetweetParams) (*TweetResponse, error) {
 	values := retweetToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/retweet/" + params.ID + ".json"
+	urlStr := c.apiURL("/1.1/statuses/retweet/") + params.ID + ".json"
 	return c.handleTweetResponse(ctx, "POST", urlStr, values)
 }
 
@@ -352,7 +352,7 @@ type UnretweetParams struct {
 // Unretweet calls the Twitter /statuses/retweet/:id.json endpoint.
 func (c *Client) Unretweet(ctx context.Context, params UnretweetParams) (*TweetResponse, error) {
 	values := unretweetToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/unretweet/" + params.ID + ".json"
+	urlStr := c.apiURL("/1.1/statuses/unretweet/") + params.ID + ".json"
 	return c.handleTweetResponse(ctx, "POST", urlStr, values)
 }
 
@@ -381,7 +381,7 @@ type OEmbedParams struct {
 // OEmbed calls the Twitter oembed endpoint.
 func (c *Client) OEmbed(ctx context.Context, params OEmbedParams) (*OEmbedResponse, error) {
 	values := oembedToQuery(params)
-	urlStr := "https://publish.twitter.com/oembed"
+	urlStr := c.publishURL("/oembed")
 	resp, err := c.do(ctx, "GET", urlStr, values)
 	if err != nil {
 		return nil, err
@@ -444,7 +444,7 @@ type RetweeterIDsParams struct {
 // RetweeterIDs calls the Twitter /statuses/retweeters/ids.json endpoint.
 func (c *Client) RetweeterIDs(ctx context.Context, params RetweeterIDsParams) (*IDsResponse, error) {
 	values := retweeterIDsToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/retweeters/ids.json"
+	urlStr := c.apiURL("/1.1/statuses/retweeters/ids.json")
 	return c.handleIDsResponse(ctx, "GET", urlStr, values)
 }
 
@@ -470,7 +470,7 @@ type LookupParams struct {
 // Lookup calls the Twitter /statuses/lookup.json endpoint.
 func (c *Client) Lookup(ctx context.Context, params LookupParams) (*TweetsResponse, error) {
 	values := lookupToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/statuses/lookup.json"
+	urlStr := c.apiURL("/1.1/statuses/lookup.json")
 	return c.handleTweetsResponse(ctx, "POST", urlStr, values)
 }
 
@@ -8,16 +8,46 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // StartFilterStream starts and returns a new Stream using the provided context,
 // stream filter parameters, and optional stream error callback.
 func (c *Client) StartFilterStream(ctx context.Context, params StreamFilterParams, errFn StreamErrFn) *Stream {
-	return newFilterStream(ctx, c, params, errFn)
+	endpoint := c.streamURL("/1.1/statuses/filter.json")
+	return newStream(ctx, streamClient{c}, "POST", endpoint, parseFilterParams(params), errFn, c.streamOpts)
+}
+
+// StartSampleStream starts and returns a new Stream of the Twitter
+// statuses/sample.json endpoint using the provided context, stream sample
+// parameters, and optional stream error callback.
+func (c *Client) StartSampleStream(ctx context.Context, params StreamSampleParams, errFn StreamErrFn) *Stream {
+	endpoint := c.streamURL("/1.1/statuses/sample.json")
+	return newStream(ctx, streamClient{c}, "GET", endpoint, parseSampleParams(params), errFn, c.streamOpts)
+}
+
+// StartStream starts and returns a new Stream of an arbitrary streaming
+// endpoint using the provided context, HTTP method, URL, query parameters, and
+// optional stream error callback. The stream is reconnected and decoded in the
+// same way as StartFilterStream.
+func (c *Client) StartStream(ctx context.Context, method, urlStr string, values url.Values, errFn StreamErrFn) *Stream {
+	return newStream(ctx, streamClient{c}, method, urlStr, values, errFn, c.streamOpts)
+}
+
+// streamClient implements the oauthClient interface for streams by making a
+// single attempt per connection. Streams reconnect according to their own
+// BackoffPolicy, so a Client's RetryPolicy must not retry beneath it.
+type streamClient struct {
+	c *Client
+}
+
+func (sc streamClient) do(ctx context.Context, method, urlStr string, values url.Values) (*http.Response, error) {
+	urlStr, body, values := encodeValues(method, urlStr, values)
+	return sc.c.executeOnce(ctx, method, urlStr, "application/x-www-form-urlencoded", body, values)
 }
 
 // StreamFilterParams represents the filter parameters used in a stream.
@@ -31,8 +61,16 @@ type StreamFilterParams struct {
 	Track         []string
 }
 
+// StreamSampleParams represents the parameters used in a sample stream.
+// https://dev.twitter.com/streaming/reference/get/statuses/sample
+type StreamSampleParams struct {
+	Language      []string
+	StallWarnings bool
+}
+
 // StreamMessage represents a message received from a Twitter stream. Fields
-// should be checked for existence before being used.
+// should be checked for existence before being used, or Kind can be used to
+// determine which field is set.
 type StreamMessage struct {
 	*Tweet
 	Delete         *DeleteMessage         `json:"delete"`
@@ -41,6 +79,127 @@ type StreamMessage struct {
 	StatusWithheld *StatusWithheldMessage `json:"status_withheld"`
 	UserWithheld   *UserWithheldMessage   `json:"user_withheld"`
 	Disconnect     *DisconnectMessage     `json:"disconnect_message"`
+	Warning        *WarningMessage        `json:"warning"`
+	Friends        []int64                `json:"friends"`
+	FriendsStr     []string               `json:"friends_str"`
+	Event          *EventMessage          `json:"-"`
+	Control        *ControlMessage        `json:"control"`
+	// Raw is the JSON that the message was decoded from.
+	Raw json.RawMessage `json:"-"`
+}
+
+// MessageKind represents the type of a StreamMessage.
+type MessageKind int
+
+// The types of StreamMessage.
+const (
+	KindUnknown MessageKind = iota
+	KindTweet
+	KindDelete
+	KindScrubGeo
+	KindLimit
+	KindStatusWithheld
+	KindUserWithheld
+	KindDisconnect
+	KindWarning
+	KindFriends
+	KindEvent
+	KindControl
+)
+
+var messageKindNames = [...]string{
+	KindUnknown:        "unknown",
+	KindTweet:          "tweet",
+	KindDelete:         "delete",
+	KindScrubGeo:       "scrub_geo",
+	KindLimit:          "limit",
+	KindStatusWithheld: "status_withheld",
+	KindUserWithheld:   "user_withheld",
+	KindDisconnect:     "disconnect",
+	KindWarning:        "warning",
+	KindFriends:        "friends",
+	KindEvent:          "event",
+	KindControl:        "control",
+}
+
+// String implements the fmt.Stringer interface.
+func (k MessageKind) String() string {
+	if k < 0 || int(k) >= len(messageKindNames) {
+		return messageKindNames[KindUnknown]
+	}
+	return messageKindNames[k]
+}
+
+// Kind returns the type of the message.
+func (sm StreamMessage) Kind() MessageKind {
+	switch {
+	case sm.Event != nil:
+		return KindEvent
+	case sm.Warning != nil:
+		return KindWarning
+	case sm.Friends != nil || sm.FriendsStr != nil:
+		return KindFriends
+	case sm.Control != nil:
+		return KindControl
+	case sm.Delete != nil:
+		return KindDelete
+	case sm.ScrubGeo != nil:
+		return KindScrubGeo
+	case sm.Limit != nil:
+		return KindLimit
+	case sm.StatusWithheld != nil:
+		return KindStatusWithheld
+	case sm.UserWithheld != nil:
+		return KindUserWithheld
+	case sm.Disconnect != nil:
+		return KindDisconnect
+	case sm.Tweet != nil:
+		return KindTweet
+	default:
+		return KindUnknown
+	}
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Event messages
+// share field names with Tweets (such as "source"), so they are decoded into
+// an EventMessage instead of the embedded Tweet.
+func (sm *StreamMessage) UnmarshalJSON(b []byte) error {
+	type message StreamMessage
+	var env struct {
+		message
+		EventName    string          `json:"event"`
+		Source       json.RawMessage `json:"source"`
+		Target       *User           `json:"target"`
+		TargetObject json.RawMessage `json:"target_object"`
+	}
+	if err := json.Unmarshal(b, &env); err != nil {
+		return err
+	}
+	*sm = StreamMessage(env.message)
+	sm.Raw = append(json.RawMessage(nil), b...)
+
+	if env.EventName != "" {
+		ev := &EventMessage{
+			Event:        env.EventName,
+			Target:       env.Target,
+			TargetObject: env.TargetObject,
+		}
+		if sm.Tweet != nil {
+			ev.CreatedAt = sm.Tweet.CreatedAt
+			sm.Tweet = nil
+		}
+		if len(env.Source) > 0 {
+			if err := json.Unmarshal(env.Source, &ev.Source); err != nil {
+				return err
+			}
+		}
+		sm.Event = ev
+		return nil
+	}
+	if sm.Tweet != nil && len(env.Source) > 0 {
+		return json.Unmarshal(env.Source, &sm.Tweet.Source)
+	}
+	return nil
 }
 
 // DeleteMessage represents a stream message that a given Tweet has been
@@ -93,6 +252,126 @@ type DisconnectMessage struct {
 	Reason     string `json:"reason"`
 }
 
+// WarningMessage represents a stream message warning that the client is
+// falling behind (stall_warnings) or that a follow limit has been exceeded.
+// https://dev.twitter.com/streaming/overview/messages-types#stall_warnings
+type WarningMessage struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	PercentFull int    `json:"percent_full"`
+	UserID      int64  `json:"user_id"`
+}
+
+// EventMessage represents a stream message notifying of an event such as a
+// favorite, follow or list change. TargetObject is the raw JSON of the object
+// the event applies to, such as a Tweet or list, if any.
+// https://dev.twitter.com/streaming/overview/messages-types#Events_event
+type EventMessage struct {
+	Event        string          `json:"event"`
+	CreatedAt    string          `json:"created_at"`
+	Source       *User           `json:"source"`
+	Target       *User           `json:"target"`
+	TargetObject json.RawMessage `json:"target_object"`
+}
+
+// TargetTweet decodes the event's target object as a Tweet, for events such as
+// favorite, unfavorite and quoted_tweet.
+func (e *EventMessage) TargetTweet() (*Tweet, error) {
+	if len(e.TargetObject) == 0 {
+		return nil, errors.New("event has no target object")
+	}
+	var t Tweet
+	if err := json.Unmarshal(e.TargetObject, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ControlMessage represents a stream message containing the URI used to
+// control a site stream.
+type ControlMessage struct {
+	ControlURI string `json:"control_uri"`
+}
+
+// streamOptions represents the Client options that apply to its streams.
+type streamOptions struct {
+	newBackoff func() BackoffPolicy
+	recorder   *StreamRecorder
+	buffer     StreamBufferParams
+	logger     Logger
+	callbacks  StreamCallbacks
+	// badLineLimit is the number of bad lines after which a connection is
+	// recycled, or zero for no limit.
+	badLineLimit int
+
+	// stallTimeout overrides stallTimeout in tests.
+	stallTimeout time.Duration
+	// updateGrace overrides updateGracePeriod in tests.
+	updateGrace time.Duration
+}
+
+// WithStreamBackoff returns a new shallow copy of the Client whose streams call
+// newBackoff to create the BackoffPolicy used for reconnects. By default,
+// streams use NewBackoff with the delays documented by Twitter.
+func (c *Client) WithStreamBackoff(newBackoff func() BackoffPolicy) *Client {
+	newC := *c
+	newC.streamOpts.newBackoff = newBackoff
+	return &newC
+}
+
+// stallTimeout is how long a connection may go without receiving data, including
+// keep-alives, before it is closed and reconnected.
+// https://dev.twitter.com/streaming/overview/connecting
+const stallTimeout = 90 * time.Second
+
+// ErrStreamStalled is the error passed to the StreamErrFn when a connection is
+// closed because nothing was received within the stall timeout.
+var ErrStreamStalled = errors.New("twitter: stream stalled")
+
+// StreamCallbacks represents optional callbacks for the lifecycle of a stream,
+// for exporting health metrics. They are called from the stream's goroutine,
+// so they should return quickly.
+type StreamCallbacks struct {
+	// OnConnect is called with the HTTP response headers each time a
+	// connection is established.
+	OnConnect func(header http.Header)
+	// OnFirstMessage is called when the first message other than a
+	// keep-alive is received on each connection.
+	OnFirstMessage func()
+	// OnStall is called when a connection is closed because nothing was
+	// received within the stall timeout.
+	OnStall func()
+	// OnDisconnect is called when a disconnect message is received.
+	OnDisconnect func(DisconnectMessage)
+	// OnBadLine is called with the raw bytes and error of each line that
+	// cannot be decoded. The line is skipped.
+	OnBadLine func(line []byte, err error)
+	// OnClose is called with the stream's shutdown error once it has
+	// stopped, before the channel returned from Done is closed.
+	OnClose func(err error)
+}
+
+// WithStreamCallbacks returns a new shallow copy of the Client whose streams
+// call the provided lifecycle callbacks.
+func (c *Client) WithStreamCallbacks(callbacks StreamCallbacks) *Client {
+	newC := *c
+	newC.streamOpts.callbacks = callbacks
+	return &newC
+}
+
+// ErrTooManyBadLines is the error passed to the StreamErrFn when a connection
+// is recycled because of too many lines that cannot be decoded.
+var ErrTooManyBadLines = errors.New("twitter: too many bad stream lines")
+
+// WithStreamBadLineLimit returns a new shallow copy of the Client whose streams
+// reconnect after receiving n lines that cannot be decoded on one connection.
+// By default, bad lines are skipped without reconnecting.
+func (c *Client) WithStreamBadLineLimit(n int) *Client {
+	newC := *c
+	newC.streamOpts.badLineLimit = n
+	return &newC
+}
+
 // StreamErrFn represents a function that is called when an error is encountered
 // in a stream and the connection will be retried. If the StreamErrFn returns
 // a non-nil error, the stream will be immediately closed with the error.
@@ -107,6 +386,7 @@ type Stream struct {
 	cancel context.CancelFunc
 
 	client   oauthClient
+	method   string
 	values   url.Values
 	endpoint string
 
@@ -114,20 +394,53 @@ type Stream struct {
 	chDone    chan struct{}
 	closeErr  error
 	errFn     StreamErrFn
+	opts      streamOptions
+
+	// mu guards the connections swapped by UpdateFilter. chNext wakes a
+	// stream waiting to reconnect when UpdateFilter opens a connection.
+	mu       sync.Mutex
+	current  *streamConn
+	next     *streamConn
+	chNext   chan struct{}
+	updateMu sync.Mutex
+
+	// dedupe remembers Tweet IDs while UpdateFilter switches connections.
+	// dedupeOn is set during a switch, and dedupeGen (guarded by mu)
+	// identifies the latest switch.
+	dedupe    tweetDeduper
+	dedupeOn  int32
+	dedupeGen int
+
+	// queue buffers messages when the stream is buffered.
+	queue       *messageQueue
+	chDelivered chan struct{}
+	statsMu     sync.Mutex
+	stats       StreamStats
+
+	logMu  sync.Mutex
+	logger Logger
 }
 
-func newFilterStream(ctx context.Context, client oauthClient, params StreamFilterParams, errFn StreamErrFn) *Stream {
-	s := Stream{
+func newStream(ctx context.Context, client oauthClient, method, endpoint string, values url.Values, errFn StreamErrFn, opts streamOptions) *Stream {
+	s := &Stream{
 		client:    client,
-		values:    parseFilterParams(params),
-		endpoint:  "https://stream.twitter.com/1.1/statuses/filter.json",
+		method:    method,
+		values:    values,
+		endpoint:  endpoint,
 		chMessage: make(chan StreamMessage),
 		chDone:    make(chan struct{}),
+		chNext:    make(chan struct{}, 1),
 		errFn:     errFn,
+		opts:      opts,
 	}
 	s.ctx, s.cancel = context.WithCancel(ctx)
+	if opts.buffer.Size > 0 {
+		s.queue = newMessageQueue(opts.buffer)
+		s.chDelivered = make(chan struct{})
+		go s.deliver()
+	}
 	go s.start()
-	return &s
+	return s
 }
 
 // Close immediately closes the stream and waits for the stream to completely
@@ -153,11 +466,32 @@ func (s *Stream) Err() error {
 // Messages returns a read-only channel that messages are sent to as they are
 // read off of the stream. Messages should be regualrly waiting on this channel,
 // otherwise the stream's queue (on Twitter's end) will fill up and cause the
-// stream to close.
+// stream to close. WithStreamBuffer can be used to absorb a slow consumer.
 func (s *Stream) Messages() <-chan StreamMessage {
 	return s.chMessage
 }
 
+// SetLogger sets the Logger that receives the stream's log events, overriding
+// the Client's Logger.
+func (s *Stream) SetLogger(l Logger) {
+	s.logMu.Lock()
+	s.logger = l
+	s.logMu.Unlock()
+}
+
+func (s *Stream) log() Logger {
+	s.logMu.Lock()
+	defer s.logMu.Unlock()
+	switch {
+	case s.logger != nil:
+		return s.logger
+	case s.opts.logger != nil:
+		return s.opts.logger
+	default:
+		return nopLogger{}
+	}
+}
+
 func (s *Stream) notifyError(boff Backoff, err error) error {
 	if s.errFn == nil {
 		return nil
@@ -168,10 +502,24 @@ func (s *Stream) notifyError(boff Backoff, err error) error {
 func (s *Stream) start() {
 	defer func() {
 		s.cancel()
+		if conn := s.takeNext(); conn != nil {
+			conn.close()
+		}
+		if s.queue != nil {
+			<-s.chDelivered
+		}
+		if fn := s.opts.callbacks.OnClose; fn != nil {
+			fn(s.closeErr)
+		}
 		close(s.chDone)
 	}()
 
-	boff := &backoff{}
+	var boff BackoffPolicy
+	if s.opts.newBackoff != nil {
+		boff = s.opts.newBackoff()
+	} else {
+		boff = NewBackoff(BackoffParams{})
+	}
 	for {
 		s.closeErr = s.makeRequest(boff)
 		select {
@@ -183,87 +531,253 @@ func (s *Stream) start() {
 		if s.closeErr != nil {
 			return
 		}
-		if d := boff.wait(); d > 0 {
+		d := boff.Wait()
+		s.log().Info("twitter: stream reconnecting", "endpoint", s.endpoint, "wait", d, "retries", boff.Retries())
+		if d > 0 {
 			select {
 			case <-s.ctx.Done():
 				s.closeErr = s.ctx.Err()
 				return
+			case <-s.chNext:
 			case <-time.After(d):
 			}
 		}
 	}
 }
 
-func (s *Stream) makeRequest(boff *backoff) error {
+func (s *Stream) makeRequest(boff BackoffPolicy) error {
+	// Use the connection opened by UpdateFilter, if there is one.
+	if conn := s.takeNext(); conn != nil {
+		return s.readConn(boff, conn)
+	}
+
 	// Create a child context for this specific request.
 	ctx, cancel := context.WithCancel(s.ctx)
-	defer cancel()
 
 	// Make HTTP request to open stream.
-	resp, err := s.client.do(ctx, "POST", s.endpoint, s.values)
+	resp, err := s.client.do(ctx, s.method, s.endpoint, s.values)
+	if conn := s.takeNext(); conn != nil {
+		// UpdateFilter opened a connection while this one was being opened
+		// with the old parameters.
+		if err == nil {
+			resp.Body.Close()
+		}
+		cancel()
+		return s.readConn(boff, conn)
+	}
 	if err != nil {
-		boff.incNetDelay()
+		cancel()
+		s.log().Warn("twitter: stream connection failed", "endpoint", s.endpoint, "error", err)
+		boff.NetworkError()
 		return s.notifyError(boff, err)
 	}
+	if resp.StatusCode == 200 {
+		s.log().Info("twitter: stream connected", "endpoint", s.endpoint)
+		return s.readConn(boff, newStreamConn(resp, cancel, s.values, s.opts.stallTimeout))
+	}
+	defer cancel()
 	defer resp.Body.Close()
 
-	// Handle HTTP response.
+	// Handle HTTP error response.
+	s.log().Warn("twitter: stream HTTP error", "endpoint", s.endpoint, "status", resp.StatusCode)
 	switch resp.StatusCode {
-	case 200:
-		err = s.readMessages(cancel, resp.Body)
-		boff.reset()
-		return s.notifyError(boff, err)
 	case 401, 403, 404, 406, 413, 416:
 		err = fmt.Errorf("%d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
 		return err
 	case 420:
 		err = errors.New("420: Rate Limited")
-		boff.incHTTPDelay(true)
+		boff.HTTPError(resp.StatusCode)
 		return s.notifyError(boff, err)
 	default:
 		err = fmt.Errorf("%d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
-		boff.incHTTPDelay(false)
+		boff.HTTPError(resp.StatusCode)
 		return s.notifyError(boff, err)
 	}
 }
 
-func (s *Stream) readMessages(cancel context.CancelFunc, r io.Reader) error {
-	scanner := bufio.NewScanner(r)
-	scanner.Split(scanLines)
+// readConn reads messages from the connection until it fails or is replaced by
+// UpdateFilter.
+func (s *Stream) readConn(boff BackoffPolicy, conn *streamConn) error {
+	s.mu.Lock()
+	if s.next != nil {
+		// UpdateFilter opened a connection before this one became current.
+		s.mu.Unlock()
+		conn.close()
+		return s.readConn(boff, s.takeNext())
+	}
+	s.current = conn
+	s.mu.Unlock()
+	if fn := s.opts.callbacks.OnConnect; fn != nil {
+		fn(conn.resp.Header)
+	}
+
+	err := s.readMessages(conn)
+	conn.close()
+	if conn.stalled() {
+		err = ErrStreamStalled
+		s.log().Warn("twitter: stream stalled", "endpoint", s.endpoint)
+		if fn := s.opts.callbacks.OnStall; fn != nil {
+			fn()
+		}
+	}
+
+	s.mu.Lock()
+	s.current = nil
+	switched := s.next != nil
+	s.mu.Unlock()
+
+	boff.Reset()
+	if switched && s.ctx.Err() == nil {
+		s.log().Info("twitter: stream filter updated", "endpoint", s.endpoint)
+		return nil
+	}
+	if s.ctx.Err() == nil {
+		s.log().Warn("twitter: stream disconnected", "endpoint", s.endpoint, "error", err)
+	}
+	return s.notifyError(boff, err)
+}
+
+// takeNext returns the connection opened by UpdateFilter, if any, and starts
+// using its parameters for future reconnects.
+func (s *Stream) takeNext() *streamConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn := s.next
+	s.next = nil
+	if conn != nil {
+		s.values = conn.values
+		select {
+		case <-s.chNext:
+		default:
+		}
+	}
+	return conn
+}
+
+func (s *Stream) readMessages(conn *streamConn) error {
 	for {
-		if err := s.readMessage(cancel, scanner); err != nil {
+		if err := s.readMessage(conn); err != nil {
 			return err
 		}
 	}
 }
 
-func (s *Stream) readMessage(cancel context.CancelFunc, scanner *bufio.Scanner) error {
-	// Set 90 second timeout on receiving a message.
-	// https://dev.twitter.com/streaming/overview/connecting
-	t := time.AfterFunc(90*time.Second, func() { cancel() })
-	ok := scanner.Scan()
-	t.Stop()
-	if !ok {
-		return scanner.Err()
+func (s *Stream) readMessage(conn *streamConn) error {
+	b, err := conn.scan()
+	if err != nil {
+		return err
 	}
-	b := scanner.Bytes()
-	if len(b) == 0 || (len(b) == 1 && b[0] == '\n') {
-		// Keep-alive.
-		log.Println("Keep-alive")
+	if s.opts.recorder != nil {
+		s.opts.recorder.Record(time.Now(), b)
+	}
+	if isKeepAlive(b) {
+		s.log().Debug("twitter: stream keep-alive", "endpoint", s.endpoint)
 		return nil
 	}
 	// Parse StreamMessage JSON.
 	var sm StreamMessage
-	err := json.Unmarshal(b, &sm)
+	err = json.Unmarshal(b, &sm)
 	if err != nil {
-		return err
+		// Skip the line, unless there have been too many on this connection.
+		s.log().Error("twitter: stream decode error", "endpoint", s.endpoint, "error", err)
+		if fn := s.opts.callbacks.OnBadLine; fn != nil {
+			fn(append([]byte(nil), b...), err)
+		}
+		conn.badLines++
+		if s.opts.badLineLimit > 0 && conn.badLines >= s.opts.badLineLimit {
+			return ErrTooManyBadLines
+		}
+		return nil
+	}
+	if !conn.received {
+		conn.received = true
+		if fn := s.opts.callbacks.OnFirstMessage; fn != nil {
+			fn()
+		}
+	}
+	if sm.Disconnect != nil {
+		s.log().Warn("twitter: stream disconnect message", "endpoint", s.endpoint,
+			"code", sm.Disconnect.Code, "reason", sm.Disconnect.Reason)
+		if fn := s.opts.callbacks.OnDisconnect; fn != nil {
+			fn(*sm.Disconnect)
+		}
 	}
-	select {
-	case <-s.ctx.Done():
-		return s.ctx.Err()
-	case s.chMessage <- sm:
+	if s.isDuplicate(&sm) {
 		return nil
 	}
+	return s.send(sm, b)
+}
+
+// isKeepAlive returns whether the line is a keep-alive.
+func isKeepAlive(b []byte) bool {
+	return len(b) == 0 || (len(b) == 1 && b[0] == '\n')
+}
+
+// streamConn represents a single HTTP connection to a streaming endpoint.
+type streamConn struct {
+	resp    *http.Response
+	cancel  context.CancelFunc
+	scanner *bufio.Scanner
+	values  url.Values
+
+	// pending are the lines that were read before the connection was handed
+	// to the stream.
+	pending [][]byte
+	// received is whether a message other than a keep-alive was read.
+	received bool
+	badLines int
+	timeout  time.Duration
+	stall    int32
+}
+
+func newStreamConn(resp *http.Response, cancel context.CancelFunc, values url.Values, timeout time.Duration) *streamConn {
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Split(scanLines)
+	if timeout <= 0 {
+		timeout = stallTimeout
+	}
+	return &streamConn{
+		resp:    resp,
+		cancel:  cancel,
+		scanner: scanner,
+		values:  values,
+		timeout: timeout,
+	}
+}
+
+// scan returns the next line from the connection. The returned bytes are only
+// valid until the next call to scan.
+func (c *streamConn) scan() ([]byte, error) {
+	if len(c.pending) > 0 {
+		b := c.pending[0]
+		c.pending = c.pending[1:]
+		return b, nil
+	}
+	t := time.AfterFunc(c.timeout, func() {
+		atomic.StoreInt32(&c.stall, 1)
+		c.cancel()
+	})
+	ok := c.scanner.Scan()
+	t.Stop()
+	if !ok {
+		if err := c.scanner.Err(); err != nil {
+			return nil, err
+		}
+		// The connection was closed by Twitter.
+		return nil, io.EOF
+	}
+	return c.scanner.Bytes(), nil
+}
+
+// stalled returns whether the connection was closed because nothing was
+// received within the stall timeout.
+func (c *streamConn) stalled() bool {
+	return atomic.LoadInt32(&c.stall) == 1
+}
+
+func (c *streamConn) close() {
+	c.cancel()
+	c.resp.Body.Close()
 }
 
 var newMsgBytes = []byte("\r\n")
@@ -307,6 +821,18 @@ func parseFilterParams(params StreamFilterParams) url.Values {
 	return values
 }
 
+func parseSampleParams(params StreamSampleParams) url.Values {
+	values := url.Values{}
+	if params.StallWarnings {
+		values.Set("stall_warnings", "true")
+	}
+	if len(params.Language) > 0 {
+		var buf bytes.Buffer
+		values.Set("language", commaSeparated(&buf, params.Language))
+	}
+	return values
+}
+
 func commaSeparated(buf *bytes.Buffer, ss []string) string {
 	buf.Reset()
 	for i, s := range ss {
@@ -0,0 +1,308 @@
+package twitter
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// BufferPolicy represents what a buffered stream does with a new message when
+// its buffer is full.
+type BufferPolicy int
+
+// The BufferPolicy values.
+const (
+	// BufferBlock stops reading from the connection until there is room.
+	BufferBlock BufferPolicy = iota
+	// BufferDropOldest drops the oldest buffered message.
+	BufferDropOldest
+	// BufferDropNewest drops the new message.
+	BufferDropNewest
+	// BufferSpill writes messages to a temporary file on disk until the
+	// consumer catches up.
+	BufferSpill
+)
+
+// StreamBufferParams represents the options for buffering stream messages
+// between the connection and the channel returned by Messages.
+type StreamBufferParams struct {
+	// Size is the number of messages buffered in memory. Zero disables
+	// buffering, so that reading from the connection waits for the consumer.
+	Size int
+	// Policy is applied when the buffer is full.
+	Policy BufferPolicy
+	// SpillDir is the directory used by BufferSpill. The default is the
+	// system temporary directory.
+	SpillDir string
+}
+
+// StreamStats represents the delivery statistics of a stream.
+type StreamStats struct {
+	// Queued is the number of messages waiting to be delivered, including
+	// Spilled.
+	Queued int
+	// Spilled is the number of queued messages on disk.
+	Spilled int
+	// Dropped is the total number of messages dropped by the BufferPolicy.
+	Dropped int64
+	// Delivered is the total number of messages sent to Messages.
+	Delivered int64
+	// Lag is the difference between the wall clock and the created_at time of
+	// the last Tweet delivered.
+	Lag time.Duration
+}
+
+// WithStreamBuffer returns a new shallow copy of the Client whose streams
+// buffer messages according to the provided parameters, so that a slow
+// consumer does not stall the connection.
+func (c *Client) WithStreamBuffer(params StreamBufferParams) *Client {
+	newC := *c
+	newC.streamOpts.buffer = params
+	return &newC
+}
+
+// Stats returns the stream's delivery statistics.
+func (s *Stream) Stats() StreamStats {
+	s.statsMu.Lock()
+	stats := s.stats
+	s.statsMu.Unlock()
+	if s.queue != nil {
+		stats.Queued, stats.Spilled, stats.Dropped = s.queue.counts()
+	}
+	return stats
+}
+
+// send delivers the message to the Messages channel, through the buffer if
+// there is one.
+func (s *Stream) send(sm StreamMessage, raw []byte) error {
+	if s.queue != nil {
+		return s.queue.push(s.ctx, sm, raw)
+	}
+	return s.sendMessage(sm)
+}
+
+func (s *Stream) sendMessage(sm StreamMessage) error {
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case s.chMessage <- sm:
+	}
+	s.statsMu.Lock()
+	s.stats.Delivered++
+	if sm.Tweet != nil {
+		if t, err := sm.CreatedAtTime(); err == nil {
+			s.stats.Lag = time.Since(t)
+		}
+	}
+	s.statsMu.Unlock()
+	return nil
+}
+
+// deliver sends buffered messages to the Messages channel until the stream is
+// closed.
+func (s *Stream) deliver() {
+	defer close(s.chDelivered)
+	defer s.queue.close()
+	for {
+		sm, err := s.queue.pop(s.ctx)
+		if err != nil {
+			return
+		}
+		if err = s.sendMessage(sm); err != nil {
+			return
+		}
+	}
+}
+
+// messageQueue represents a FIFO buffer of stream messages. With BufferSpill,
+// the raw lines of messages that do not fit in memory are written to disk and
+// decoded again when delivered.
+type messageQueue struct {
+	params   StreamBufferParams
+	notEmpty chan struct{}
+	notFull  chan struct{}
+
+	mu      sync.Mutex
+	items   []StreamMessage
+	spill   *spillFile
+	dropped int64
+	closed  bool
+}
+
+func newMessageQueue(params StreamBufferParams) *messageQueue {
+	return &messageQueue{
+		params:   params,
+		notEmpty: make(chan struct{}, 1),
+		notFull:  make(chan struct{}, 1),
+	}
+}
+
+func (q *messageQueue) counts() (queued, spilled int, dropped int64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.spill != nil {
+		spilled = q.spill.n
+	}
+	return len(q.items) + spilled, spilled, q.dropped
+}
+
+func (q *messageQueue) push(ctx context.Context, sm StreamMessage, raw []byte) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ctx.Err()
+		}
+		spilling := q.spill != nil && q.spill.n > 0
+		if len(q.items) < q.params.Size && !spilling {
+			q.items = append(q.items, sm)
+			q.mu.Unlock()
+			signal(q.notEmpty)
+			return nil
+		}
+
+		switch q.params.Policy {
+		case BufferDropNewest:
+			q.dropped++
+			q.mu.Unlock()
+			return nil
+		case BufferDropOldest:
+			q.items[0] = StreamMessage{}
+			q.items = append(q.items[1:], sm)
+			q.dropped++
+			q.mu.Unlock()
+			return nil
+		case BufferSpill:
+			err := q.spillLine(raw)
+			q.mu.Unlock()
+			if err == nil {
+				signal(q.notEmpty)
+			}
+			return err
+		}
+
+		// Block until the consumer makes room.
+		q.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-q.notFull:
+		}
+	}
+}
+
+func (q *messageQueue) spillLine(raw []byte) error {
+	if q.spill == nil {
+		f, err := ioutil.TempFile(q.params.SpillDir, "twitter-stream-")
+		if err != nil {
+			return err
+		}
+		q.spill = &spillFile{f: f}
+	}
+	return q.spill.write(raw)
+}
+
+func (q *messageQueue) pop(ctx context.Context) (StreamMessage, error) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			sm := q.items[0]
+			q.items[0] = StreamMessage{}
+			q.items = q.items[1:]
+			q.mu.Unlock()
+			signal(q.notFull)
+			return sm, nil
+		}
+		if q.spill != nil && q.spill.n > 0 {
+			raw, err := q.spill.read()
+			if err != nil {
+				// The spilled messages cannot be recovered.
+				q.dropped += int64(q.spill.n)
+				q.spill.reset()
+			}
+			q.mu.Unlock()
+			var sm StreamMessage
+			if err != nil || json.Unmarshal(raw, &sm) != nil {
+				continue
+			}
+			return sm, nil
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return StreamMessage{}, ctx.Err()
+		case <-q.notEmpty:
+		}
+	}
+}
+
+func (q *messageQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	if q.spill != nil {
+		q.spill.close()
+		q.spill = nil
+	}
+}
+
+// signal notifies a waiter on ch without blocking.
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// spillFile represents a FIFO of length-prefixed lines in a temporary file.
+// The file is truncated whenever it is emptied.
+type spillFile struct {
+	f        *os.File
+	n        int
+	writeOff int64
+	readOff  int64
+}
+
+func (sf *spillFile) write(raw []byte) error {
+	buf := make([]byte, 4+len(raw))
+	binary.BigEndian.PutUint32(buf, uint32(len(raw)))
+	copy(buf[4:], raw)
+	if _, err := sf.f.WriteAt(buf, sf.writeOff); err != nil {
+		return err
+	}
+	sf.writeOff += int64(len(buf))
+	sf.n++
+	return nil
+}
+
+func (sf *spillFile) read() ([]byte, error) {
+	var size [4]byte
+	if _, err := sf.f.ReadAt(size[:], sf.readOff); err != nil {
+		return nil, err
+	}
+	raw := make([]byte, binary.BigEndian.Uint32(size[:]))
+	if _, err := sf.f.ReadAt(raw, sf.readOff+4); err != nil {
+		return nil, err
+	}
+	sf.readOff += 4 + int64(len(raw))
+	sf.n--
+	if sf.n == 0 {
+		sf.reset()
+	}
+	return raw, nil
+}
+
+func (sf *spillFile) reset() {
+	sf.n, sf.writeOff, sf.readOff = 0, 0, 0
+	sf.f.Truncate(0)
+}
+
+func (sf *spillFile) close() {
+	sf.f.Close()
+	os.Remove(sf.f.Name())
+}
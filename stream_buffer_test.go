@@ -0,0 +1,99 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stream buffering", func() {
+	tweets := func(n int) string {
+		var body string
+		for i := 1; i <= n; i++ {
+			body += `{"id_str":"` + strconv.Itoa(i) + `"}` + "\r\n"
+		}
+		return body
+	}
+
+	// drain reads messages until none arrive for a short while.
+	drain := func(s *Stream) []string {
+		var ids []string
+		for {
+			select {
+			case msg := <-s.Messages():
+				ids = append(ids, msg.IDStr)
+			case <-time.After(100 * time.Millisecond):
+				return ids
+			}
+		}
+	}
+
+	start := func(body string, params StreamBufferParams) *Stream {
+		return newStream(context.Background(), newStreamMock(body), "GET", "", nil, nil, streamOptions{buffer: params})
+	}
+
+	It("should block reading when the buffer is full", func() {
+		s := start(tweets(5), StreamBufferParams{Size: 2})
+		Eventually(func() int { return s.Stats().Queued }).Should(Equal(2))
+		Ω(drain(s)).Should(Equal([]string{"1", "2", "3", "4", "5"}))
+		stats := s.Stats()
+		Ω(stats.Dropped).Should(BeZero())
+		Ω(stats.Delivered).Should(BeEquivalentTo(5))
+		s.Close()
+	})
+
+	It("should drop the newest messages", func() {
+		s := start(tweets(5), StreamBufferParams{Size: 2, Policy: BufferDropNewest})
+		Eventually(func() int64 { return s.Stats().Dropped }).Should(BeNumerically(">=", 2))
+		ids := drain(s)
+		Ω(ids).Should(Equal([]string{"1", "2", "3", "4", "5"}[:len(ids)]))
+		Ω(int(s.Stats().Dropped) + len(ids)).Should(Equal(5))
+		s.Close()
+	})
+
+	It("should drop the oldest messages", func() {
+		s := start(tweets(5), StreamBufferParams{Size: 2, Policy: BufferDropOldest})
+		Eventually(func() int64 { return s.Stats().Dropped }).Should(BeNumerically(">=", 2))
+		ids := drain(s)
+		Ω(ids[len(ids)-2:]).Should(Equal([]string{"4", "5"}))
+		Ω(int(s.Stats().Dropped) + len(ids)).Should(Equal(5))
+		s.Close()
+	})
+
+	It("should spill to disk and deliver in order", func() {
+		dir, err := ioutil.TempDir("", "twitter-spill")
+		Ω(err).ShouldNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		s := start(tweets(5)+`{"event":"follow","source":{"id_str":"9"}}`+"\r\n", StreamBufferParams{Size: 1, Policy: BufferSpill, SpillDir: dir})
+		Eventually(func() int { return s.Stats().Spilled }).Should(BeNumerically(">=", 3))
+		files, _ := ioutil.ReadDir(dir)
+		Ω(files).Should(HaveLen(1))
+
+		for i := 1; i <= 5; i++ {
+			Ω((<-s.Messages()).IDStr).Should(Equal(strconv.Itoa(i)))
+		}
+		msg := <-s.Messages()
+		Ω(msg.Kind()).Should(Equal(KindEvent))
+		Ω(msg.Event.Source.IDStr).Should(Equal("9"))
+		Ω(s.Stats().Queued).Should(BeZero())
+
+		s.Close()
+		files, _ = ioutil.ReadDir(dir)
+		Ω(files).Should(BeEmpty())
+	})
+
+	It("should report the lag of delivered Tweets", func() {
+		createdAt := time.Now().Add(-time.Hour).Format(time.RubyDate)
+		s := start(`{"id_str":"1","created_at":"`+createdAt+`"}`+"\r\n", StreamBufferParams{})
+		<-s.Messages()
+		Eventually(func() time.Duration { return s.Stats().Lag }).Should(BeNumerically(">=", time.Hour))
+		Ω(s.Stats().Lag).Should(BeNumerically("<", time.Hour+time.Minute))
+		s.Close()
+	})
+})
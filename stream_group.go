@@ -0,0 +1,300 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// The per-connection limits of the Twitter statuses/filter.json endpoint.
+// https://dev.twitter.com/streaming/reference/post/statuses/filter
+const (
+	maxStreamTrack     = 400
+	maxStreamFollow    = 5000
+	maxStreamLocations = 25
+)
+
+// ErrNotEnoughCredentials is returned when a StreamGroup needs more shards than
+// there are credentials to open them with.
+var ErrNotEnoughCredentials = errors.New("twitter: not enough credentials for stream shards")
+
+// ShardErrFn represents a callback that is called when a shard of a
+// StreamGroup encounters an error. It behaves like StreamErrFn for the shard
+// with the provided index.
+type ShardErrFn func(shard int, b Backoff, err error) error
+
+// StreamGroupParams represents the parameters used to start a StreamGroup.
+type StreamGroupParams struct {
+	// Filter is partitioned across the shards. FilterLevel, Language and
+	// StallWarnings are applied to every shard.
+	Filter StreamFilterParams
+	// Credentials are used to open each shard, one per shard, since Twitter
+	// only allows one filter stream per token. If empty, the Client's
+	// credentials are used and the filter must fit in a single shard.
+	Credentials []AccessCredentials
+	// MaxTrack, MaxFollow and MaxLocations override the number of track
+	// terms, follow IDs and location boxes per shard. Zero uses the limits
+	// documented by Twitter.
+	MaxTrack     int
+	MaxFollow    int
+	MaxLocations int
+	// ErrFn is called when a shard encounters an error.
+	ErrFn ShardErrFn
+}
+
+// ShardStats represents the health of a single shard of a StreamGroup.
+type ShardStats struct {
+	Params StreamFilterParams
+	// Connected reports whether the shard has received a message since its
+	// last error.
+	Connected   bool
+	Messages    int
+	LastMessage time.Time
+	LastError   error
+	// Retries, Waited and NextWait are the shard's Backoff state as of its
+	// last error.
+	Retries  int
+	Waited   time.Duration
+	NextWait time.Duration
+	// Closed reports whether the shard has stopped, with Err set to the
+	// reason.
+	Closed bool
+	Err    error
+}
+
+// StreamGroup represents a filter stream that is partitioned across multiple
+// connections to get around the per-connection limits. Messages from every
+// shard are merged into a single channel, and Tweets that match more than one
+// shard are only sent once. A shard that stops does not stop the others.
+type StreamGroup struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	shards    []*streamShard
+	chMessage chan StreamMessage
+	chDone    chan struct{}
+	dedupe    tweetDeduper
+
+	mu       sync.Mutex
+	closeErr error
+}
+
+type streamShard struct {
+	stream *Stream
+
+	mu    sync.Mutex
+	stats ShardStats
+}
+
+// StartFilterStreamGroup starts and returns a new StreamGroup of the Twitter
+// statuses/filter.json endpoint, splitting the filter into as many shards as
+// the per-connection limits require.
+func (c *Client) StartFilterStreamGroup(ctx context.Context, params StreamGroupParams) (*StreamGroup, error) {
+	endpoint := c.streamURL("/1.1/statuses/filter.json")
+	return newStreamGroup(ctx, streamClient{c}, endpoint, params, c.streamOpts)
+}
+
+func newStreamGroup(ctx context.Context, client oauthClient, endpoint string, params StreamGroupParams, opts streamOptions) (*StreamGroup, error) {
+	filters := shardFilterParams(params)
+	if len(filters) > 1 && len(filters) > len(params.Credentials) {
+		return nil, ErrNotEnoughCredentials
+	}
+
+	g := &StreamGroup{
+		shards:    make([]*streamShard, len(filters)),
+		chMessage: make(chan StreamMessage),
+		chDone:    make(chan struct{}),
+	}
+	g.ctx, g.cancel = context.WithCancel(ctx)
+
+	var wg sync.WaitGroup
+	for i, filter := range filters {
+		shardCtx := g.ctx
+		if len(params.Credentials) > 0 {
+			shardCtx = WithAccessCredentials(g.ctx, params.Credentials[i])
+		}
+		shard := &streamShard{stats: ShardStats{Params: filter}}
+		shard.stream = newStream(shardCtx, client, "POST", endpoint, parseFilterParams(filter), g.errFn(i, shard, params.ErrFn), opts)
+		g.shards[i] = shard
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.readShard(shard)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		g.cancel()
+		close(g.chDone)
+	}()
+	return g, nil
+}
+
+// Close immediately closes every shard and waits for the group to completely
+// close before returning its shutdown error.
+func (g *StreamGroup) Close() error {
+	g.cancel()
+	<-g.chDone
+	return g.Err()
+}
+
+// Done returns a channel that is closed when every shard has completely
+// shutdown.
+func (g *StreamGroup) Done() <-chan struct{} {
+	return g.chDone
+}
+
+// Err returns the shutdown error of the first shard to stop. This should only
+// be called after the channel returned from Done has been closed.
+func (g *StreamGroup) Err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closeErr
+}
+
+// Messages returns a read-only channel that messages from every shard are sent
+// to as they are read.
+func (g *StreamGroup) Messages() <-chan StreamMessage {
+	return g.chMessage
+}
+
+// Shards returns the health of each shard, in order.
+func (g *StreamGroup) Shards() []ShardStats {
+	stats := make([]ShardStats, len(g.shards))
+	for i, shard := range g.shards {
+		shard.mu.Lock()
+		stats[i] = shard.stats
+		shard.mu.Unlock()
+	}
+	return stats
+}
+
+func (g *StreamGroup) errFn(i int, shard *streamShard, errFn ShardErrFn) StreamErrFn {
+	return func(b Backoff, err error) error {
+		shard.mu.Lock()
+		shard.stats.Connected = false
+		shard.stats.LastError = err
+		shard.stats.Retries = b.Retries()
+		shard.stats.Waited = b.Waited()
+		shard.stats.NextWait = b.NextWait()
+		shard.mu.Unlock()
+		if errFn == nil {
+			return nil
+		}
+		return errFn(i, b, err)
+	}
+}
+
+func (g *StreamGroup) readShard(shard *streamShard) {
+	defer func() {
+		err := shard.stream.Close()
+		shard.mu.Lock()
+		shard.stats.Connected = false
+		shard.stats.Closed = true
+		shard.stats.Err = err
+		shard.mu.Unlock()
+		g.setCloseErr(err)
+	}()
+
+	for {
+		select {
+		case <-shard.stream.Done():
+			return
+		case msg := <-shard.stream.Messages():
+			shard.mu.Lock()
+			shard.stats.Connected = true
+			shard.stats.Messages++
+			shard.stats.LastMessage = time.Now()
+			shard.mu.Unlock()
+			if msg.Tweet != nil && msg.IDStr != "" && g.dedupe.seen(msg.IDStr) {
+				continue
+			}
+			select {
+			case <-g.ctx.Done():
+				return
+			case g.chMessage <- msg:
+			}
+		}
+	}
+}
+
+func (g *StreamGroup) setCloseErr(err error) {
+	g.mu.Lock()
+	if g.closeErr == nil {
+		g.closeErr = err
+	}
+	g.mu.Unlock()
+}
+
+// shardFilterParams splits the filter into the fewest shards that fit within
+// the per-connection limits, spreading the filters evenly between them.
+func shardFilterParams(params StreamGroupParams) []StreamFilterParams {
+	maxTrack, maxFollow, maxLocations := params.MaxTrack, params.MaxFollow, params.MaxLocations
+	if maxTrack <= 0 {
+		maxTrack = maxStreamTrack
+	}
+	if maxFollow <= 0 {
+		maxFollow = maxStreamFollow
+	}
+	if maxLocations <= 0 {
+		maxLocations = maxStreamLocations
+	}
+
+	// Each location box is four coordinates.
+	f := params.Filter
+	boxes := (len(f.Locations) + 3) / 4
+	n := 1
+	for _, m := range []int{
+		(len(f.Track) + maxTrack - 1) / maxTrack,
+		(len(f.Follow) + maxFollow - 1) / maxFollow,
+		(boxes + maxLocations - 1) / maxLocations,
+	} {
+		if m > n {
+			n = m
+		}
+	}
+
+	track := splitStrings(f.Track, n, 1)
+	follow := splitStrings(f.Follow, n, 1)
+	locations := splitStrings(f.Locations, n, 4)
+	shards := make([]StreamFilterParams, n)
+	for i := range shards {
+		shards[i] = StreamFilterParams{
+			FilterLevel:   f.FilterLevel,
+			Follow:        follow[i],
+			Language:      f.Language,
+			Locations:     locations[i],
+			StallWarnings: f.StallWarnings,
+			Track:         track[i],
+		}
+	}
+	return shards
+}
+
+// splitStrings splits s into n parts of nearly equal size, keeping groups of
+// the provided size together.
+func splitStrings(s []string, n, group int) [][]string {
+	parts := make([][]string, n)
+	groups := (len(s) + group - 1) / group
+	start := 0
+	for i := range parts {
+		end := start + (groups/n+boolInt(i < groups%n))*group
+		if end > len(s) {
+			end = len(s)
+		}
+		if end > start {
+			parts[i] = s[start:end]
+		}
+		start = end
+	}
+	return parts
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,125 @@
+package twitter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// streamFuncMock implements the oauthClient interface by writing the body
+// returned by fn, which stays open until the request's context is cancelled.
+type streamFuncMock func(ctx context.Context, values url.Values) (int, string)
+
+func (fn streamFuncMock) do(ctx context.Context, method, urlStr string, values url.Values) (*http.Response, error) {
+	status, body := fn(ctx, values)
+	pr, pw := io.Pipe()
+	go func() {
+		io.WriteString(pw, body)
+		<-ctx.Done()
+		pw.CloseWithError(ctx.Err())
+	}()
+	return &http.Response{
+		StatusCode: status,
+		Body:       pr,
+	}, nil
+}
+
+var _ = Describe("StreamGroup", func() {
+	terms := func(n int) []string {
+		s := make([]string, n)
+		for i := range s {
+			s[i] = "term" + strconv.Itoa(i)
+		}
+		return s
+	}
+
+	Context("shardFilterParams", func() {
+		It("should spread filters evenly across the fewest shards", func() {
+			shards := shardFilterParams(StreamGroupParams{
+				Filter: StreamFilterParams{
+					Track:     terms(900),
+					Follow:    []string{"1", "2"},
+					Locations: []string{"-1", "-1", "1", "1", "-2", "-2", "2", "2"},
+					Language:  []string{"en"},
+				},
+			})
+			Ω(shards).Should(HaveLen(3))
+			for _, shard := range shards {
+				Ω(shard.Track).Should(HaveLen(300))
+				Ω(shard.Language).Should(Equal([]string{"en"}))
+			}
+			Ω(shards[0].Follow).Should(Equal([]string{"1"}))
+			Ω(shards[1].Follow).Should(Equal([]string{"2"}))
+			Ω(shards[2].Follow).Should(BeNil())
+			Ω(shards[0].Locations).Should(Equal([]string{"-1", "-1", "1", "1"}))
+			Ω(shards[1].Locations).Should(Equal([]string{"-2", "-2", "2", "2"}))
+		})
+
+		It("should respect overridden limits", func() {
+			shards := shardFilterParams(StreamGroupParams{
+				Filter:    StreamFilterParams{Track: terms(5)},
+				MaxTrack:  2,
+				MaxFollow: 1,
+			})
+			Ω(shards).Should(HaveLen(3))
+			Ω(shards[0].Track).Should(HaveLen(2))
+			Ω(shards[2].Track).Should(HaveLen(1))
+		})
+	})
+
+	It("should require a credential for each shard", func() {
+		_, err := newStreamGroup(context.Background(), streamFuncMock(nil), "", StreamGroupParams{
+			Filter:      StreamFilterParams{Track: terms(3)},
+			Credentials: []AccessCredentials{{Token: "a"}},
+			MaxTrack:    1,
+		}, streamOptions{})
+		Ω(err).Should(Equal(ErrNotEnoughCredentials))
+	})
+
+	It("should merge shards, dedupe Tweets and report shard health", func() {
+		var mu sync.Mutex
+		tokens := map[string]string{}
+		m := streamFuncMock(func(ctx context.Context, values url.Values) (int, string) {
+			track := values.Get("track")
+			mu.Lock()
+			tokens[track] = ctx.Value(accCredsKey).(*oauth.Credentials).Token
+			mu.Unlock()
+			// Every shard matches Tweet 1.
+			id := strings.TrimPrefix(track, "term")
+			return 200, `{"id_str":"1"}` + "\r\n" + `{"id_str":"10` + id + `"}` + "\r\n"
+		})
+		g, err := newStreamGroup(context.Background(), m, "", StreamGroupParams{
+			Filter:      StreamFilterParams{Track: terms(3)},
+			Credentials: []AccessCredentials{{Token: "a"}, {Token: "b"}, {Token: "c"}},
+			MaxTrack:    1,
+		}, streamOptions{})
+		Ω(err).ShouldNot(HaveOccurred())
+
+		var ids []string
+		for i := 0; i < 4; i++ {
+			ids = append(ids, (<-g.Messages()).IDStr)
+		}
+		Ω(ids).Should(ConsistOf("1", "100", "101", "102"))
+		Consistently(g.Messages()).ShouldNot(Receive())
+
+		stats := g.Shards()
+		Ω(stats).Should(HaveLen(3))
+		for i, s := range stats {
+			Ω(s.Params.Track).Should(Equal([]string{"term" + strconv.Itoa(i)}))
+			Ω(s.Connected).Should(BeTrue())
+			Ω(s.Messages).Should(Equal(2))
+		}
+
+		Ω(g.Close()).Should(Equal(context.Canceled))
+		Ω(g.Shards()[0].Closed).Should(BeTrue())
+		Ω(tokens).Should(Equal(map[string]string{"term0": "a", "term1": "b", "term2": "c"}))
+	})
+})
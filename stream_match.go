@@ -0,0 +1,317 @@
+package twitter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FilterMatcher decides locally whether a Tweet matches a StreamFilterParams in
+// the same way as the Twitter statuses/filter.json endpoint, and which filters
+// it matched. It can be used to attribute stream Tweets to keywords, or to
+// filter replayed or backfilled Tweets the same way a live stream would.
+// https://dev.twitter.com/streaming/overview/request-parameters
+type FilterMatcher struct {
+	track       []trackPhrase
+	follow      map[string]bool
+	locations   [][4]float64
+	languages   map[string]bool
+	filterLevel int
+}
+
+type trackPhrase struct {
+	phrase string
+	terms  []string
+}
+
+// FilterMatch represents the filters that a Tweet matched.
+type FilterMatch struct {
+	// Track is the matched track phrases, as provided in the params.
+	Track []string
+	// Follow is the matched follow user IDs.
+	Follow []string
+	// Locations is the index of each matched location box, where box i is
+	// Locations[4*i : 4*i+4] of the params.
+	Locations []int
+}
+
+// Matched returns whether any filter was matched.
+func (m FilterMatch) Matched() bool {
+	return len(m.Track) > 0 || len(m.Follow) > 0 || len(m.Locations) > 0
+}
+
+var filterLevels = map[string]int{"none": 0, "low": 1, "medium": 2}
+
+// NewFilterMatcher returns a new FilterMatcher for the provided filter
+// parameters. An error is returned if the locations are not a list of
+// longitude/latitude pairs forming south-west and north-east corners.
+func NewFilterMatcher(params StreamFilterParams) (*FilterMatcher, error) {
+	m := &FilterMatcher{
+		follow:      make(map[string]bool, len(params.Follow)),
+		languages:   make(map[string]bool, len(params.Language)),
+		filterLevel: filterLevels[strings.ToLower(params.FilterLevel)],
+	}
+	for _, phrase := range params.Track {
+		terms := strings.Fields(strings.ToLower(phrase))
+		if len(terms) > 0 {
+			m.track = append(m.track, trackPhrase{phrase: phrase, terms: terms})
+		}
+	}
+	for _, id := range params.Follow {
+		m.follow[strings.TrimSpace(id)] = true
+	}
+	for _, lang := range params.Language {
+		m.languages[strings.ToLower(strings.TrimSpace(lang))] = true
+	}
+	if len(params.Locations)%4 != 0 {
+		return nil, fmt.Errorf("locations must be sets of 4 coordinates, got %d", len(params.Locations))
+	}
+	for i := 0; i < len(params.Locations); i += 4 {
+		var box [4]float64
+		for j := range box {
+			f, err := strconv.ParseFloat(strings.TrimSpace(params.Locations[i+j]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid location %q: %v", params.Locations[i+j], err)
+			}
+			box[j] = f
+		}
+		m.locations = append(m.locations, box)
+	}
+	return m, nil
+}
+
+// Matches returns whether the Tweet matches the filter.
+func (m *FilterMatcher) Matches(t *Tweet) bool {
+	return m.Match(t).Matched()
+}
+
+// Match returns the filters that the Tweet matches. As with a live stream,
+// track, follow and locations are combined with OR, while the language and
+// filter level must also match.
+func (m *FilterMatcher) Match(t *Tweet) FilterMatch {
+	var match FilterMatch
+	if len(m.languages) > 0 && !m.languages[strings.ToLower(t.Lang)] {
+		return match
+	}
+	if level, ok := filterLevels[t.FilterLevel]; ok && level < m.filterLevel {
+		return match
+	}
+	if len(m.track) > 0 {
+		match.Track = m.matchTrack(t)
+	}
+	if len(m.follow) > 0 {
+		match.Follow = m.matchFollow(t)
+	}
+	if len(m.locations) > 0 {
+		match.Locations = m.matchLocations(t)
+	}
+	return match
+}
+
+// matchTrack matches phrases whose terms all appear, in any order, in the text,
+// hashtags, mentions or URLs of the Tweet or the Tweet it retweets or quotes.
+func (m *FilterMatcher) matchTrack(t *Tweet) []string {
+	words := trackWords{tokens: make(map[string]bool)}
+	words.addTweet(t)
+	if t.RetweetedStatus != nil {
+		words.addTweet(t.RetweetedStatus)
+	}
+	if t.QuotedStatus != nil {
+		words.addTweet(t.QuotedStatus)
+	}
+
+	var matched []string
+	for _, p := range m.track {
+		all := true
+		for _, term := range p.terms {
+			if !words.has(term) {
+				all = false
+				break
+			}
+		}
+		if all {
+			matched = append(matched, p.phrase)
+		}
+	}
+	return matched
+}
+
+// trackWords represents the words of a Tweet that track terms are matched
+// against.
+type trackWords struct {
+	tokens map[string]bool
+	urls   []string
+}
+
+func (w *trackWords) addTweet(t *Tweet) {
+	text, entities := t.Text, t.Entities
+	if t.FullText != "" {
+		text = t.FullText
+	}
+	if t.ExtendedTweet != nil {
+		text, entities = t.ExtendedTweet.FullText, t.ExtendedTweet.Entities
+	}
+
+	// Words match with or without surrounding punctuation, so "twitter"
+	// matches "Twitter.", "#twitter" and "@twitter".
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		w.tokens[word] = true
+		w.tokens[strings.TrimFunc(word, isNotWordRune)] = true
+		w.tokens[strings.TrimFunc(word, func(r rune) bool {
+			return r != '#' && r != '@' && isNotWordRune(r)
+		})] = true
+	}
+	for _, h := range entities.Hashtags {
+		tag := strings.ToLower(h.Text)
+		w.tokens[tag] = true
+		w.tokens["#"+tag] = true
+	}
+	for _, u := range entities.UserMentions {
+		name := strings.ToLower(u.ScreenName)
+		w.tokens[name] = true
+		w.tokens["@"+name] = true
+	}
+	for _, u := range entities.URLs {
+		w.addURL(u.ExpandedURL)
+		w.addURL(u.DisplayURL)
+	}
+	for _, u := range entities.Media {
+		w.addURL(u.ExpandedURL)
+		w.addURL(u.DisplayURL)
+	}
+}
+
+// addURL adds each part of the URL split on punctuation, so that "example com"
+// matches "http://example.com/page".
+func (w *trackWords) addURL(u string) {
+	if u == "" {
+		return
+	}
+	u = strings.ToLower(u)
+	u = strings.TrimPrefix(strings.TrimPrefix(u, "http://"), "https://")
+	w.urls = append(w.urls, u)
+	for _, part := range strings.FieldsFunc(u, isNotWordRune) {
+		w.tokens[part] = true
+	}
+}
+
+// has returns whether the term is a word of the Tweet. Terms containing
+// punctuation, such as "example.com", also match anywhere in a URL.
+func (w *trackWords) has(term string) bool {
+	if w.tokens[term] {
+		return true
+	}
+	if strings.IndexFunc(term, isNotWordRune) < 0 {
+		return false
+	}
+	for _, u := range w.urls {
+		if strings.Contains(u, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
+
+// matchFollow matches Tweets created or retweeted by a followed user, replies
+// to a followed user, retweets of a followed user, and manual replies that
+// start by mentioning a followed user. Other mentions do not match.
+func (m *FilterMatcher) matchFollow(t *Tweet) []string {
+	var matched []string
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && m.follow[id] && !seen[id] {
+			seen[id] = true
+			matched = append(matched, id)
+		}
+	}
+	add(userIDStr(&t.User))
+	add(t.InReplyToUserIDStr)
+	if t.InReplyToUserIDStr == "" && t.InReplyToUserID != 0 {
+		add(strconv.FormatInt(t.InReplyToUserID, 10))
+	}
+	if t.RetweetedStatus != nil {
+		add(userIDStr(&t.RetweetedStatus.User))
+	}
+	for _, u := range t.Entities.UserMentions {
+		if len(u.Indices) > 0 && u.Indices[0] == 0 {
+			id := u.IDStr
+			if id == "" && u.ID != 0 {
+				id = strconv.FormatInt(u.ID, 10)
+			}
+			add(id)
+		}
+	}
+	return matched
+}
+
+func userIDStr(u *User) string {
+	if u.IDStr != "" {
+		return u.IDStr
+	}
+	if u.ID != 0 {
+		return strconv.FormatInt(u.ID, 10)
+	}
+	return ""
+}
+
+// matchLocations matches the Tweet's coordinates against each box. If the Tweet
+// has no coordinates, its place's bounding box is checked for intersection.
+func (m *FilterMatcher) matchLocations(t *Tweet) []int {
+	var matched []int
+	if t.Coordinates != nil {
+		lon, lat := t.Coordinates.Coordinates[0], t.Coordinates.Coordinates[1]
+		for i, box := range m.locations {
+			if lon >= box[0] && lon <= box[2] && lat >= box[1] && lat <= box[3] {
+				matched = append(matched, i)
+			}
+		}
+		return matched
+	}
+	if t.Place == nil {
+		return nil
+	}
+	place, ok := placeBounds(t.Place.BoundingBox)
+	if !ok {
+		return nil
+	}
+	for i, box := range m.locations {
+		if place[0] <= box[2] && place[2] >= box[0] && place[1] <= box[3] && place[3] >= box[1] {
+			matched = append(matched, i)
+		}
+	}
+	return matched
+}
+
+// placeBounds returns the south-west and north-east corners of a bounding box
+// as [west, south, east, north].
+func placeBounds(b BoundingBox) ([4]float64, bool) {
+	var bounds [4]float64
+	first := true
+	for _, ring := range b.Coordinates {
+		for _, p := range ring {
+			if first {
+				bounds = [4]float64{p[0], p[1], p[0], p[1]}
+				first = false
+				continue
+			}
+			if p[0] < bounds[0] {
+				bounds[0] = p[0]
+			}
+			if p[1] < bounds[1] {
+				bounds[1] = p[1]
+			}
+			if p[0] > bounds[2] {
+				bounds[2] = p[0]
+			}
+			if p[1] > bounds[3] {
+				bounds[3] = p[1]
+			}
+		}
+	}
+	return bounds, !first
+}
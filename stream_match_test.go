@@ -0,0 +1,103 @@
+package twitter
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FilterMatcher", func() {
+	newMatcher := func(params StreamFilterParams) *FilterMatcher {
+		m, err := NewFilterMatcher(params)
+		Ω(err).ShouldNot(HaveOccurred())
+		return m
+	}
+
+	Context("track", func() {
+		It("should match words case-insensitively with surrounding punctuation", func() {
+			m := newMatcher(StreamFilterParams{Track: []string{"twitter"}})
+			for _, text := range []string{"TWITTER", `"Twitter"`, "twitter.", "#twitter", "@twitter"} {
+				Ω(m.Matches(&Tweet{Text: text})).Should(BeTrue(), text)
+			}
+			for _, text := range []string{"TwitterTracker", "http://www.twitter.com"} {
+				Ω(m.Matches(&Tweet{Text: text})).Should(BeFalse(), text)
+			}
+		})
+
+		It("should require every term of a phrase in any order", func() {
+			m := newMatcher(StreamFilterParams{Track: []string{"new york", "go"}})
+			match := m.Match(&Tweet{Text: "York is new"})
+			Ω(match.Track).Should(Equal([]string{"new york"}))
+			Ω(m.Matches(&Tweet{Text: "new jersey"})).Should(BeFalse())
+		})
+
+		It("should match entities and URLs", func() {
+			m := newMatcher(StreamFilterParams{Track: []string{"golang", "gopher", "example com", "blog.golang.org"}})
+			t := &Tweet{
+				Text: "see https://t.co/abc",
+				Entities: Entities{
+					Hashtags:     []HashtagEntity{{Text: "GoLang"}},
+					UserMentions: []UserMentionEntity{{ScreenName: "Gopher"}},
+					URLs: []URLEntity{
+						{ExpandedURL: "https://example.com/page", DisplayURL: "example.com/page"},
+						{ExpandedURL: "https://blog.golang.org/post"},
+					},
+				},
+			}
+			Ω(m.Match(t).Track).Should(Equal([]string{"golang", "gopher", "example com", "blog.golang.org"}))
+		})
+
+		It("should match the text of extended, retweeted and quoted Tweets", func() {
+			m := newMatcher(StreamFilterParams{Track: []string{"hidden", "quoted"}})
+			t := &Tweet{
+				Text:            "RT @someone: short",
+				RetweetedStatus: &Tweet{ExtendedTweet: &ExtendedTweet{FullText: "short text with hidden word"}},
+				QuotedStatus:    &Tweet{Text: "quoted"},
+			}
+			Ω(m.Match(t).Track).Should(Equal([]string{"hidden", "quoted"}))
+		})
+	})
+
+	Context("follow", func() {
+		It("should match authors, retweets, replies and manual replies only", func() {
+			m := newMatcher(StreamFilterParams{Follow: []string{"1"}})
+			Ω(m.Matches(&Tweet{User: User{IDStr: "1"}})).Should(BeTrue())
+			Ω(m.Matches(&Tweet{InReplyToUserIDStr: "1"})).Should(BeTrue())
+			Ω(m.Matches(&Tweet{RetweetedStatus: &Tweet{User: User{IDStr: "1"}}})).Should(BeTrue())
+			Ω(m.Matches(&Tweet{Entities: Entities{UserMentions: []UserMentionEntity{{IDStr: "1", Indices: []int{0, 4}}}}})).Should(BeTrue())
+			Ω(m.Matches(&Tweet{Entities: Entities{UserMentions: []UserMentionEntity{{IDStr: "1", Indices: []int{5, 9}}}}})).Should(BeFalse())
+		})
+	})
+
+	Context("locations", func() {
+		m := func() *FilterMatcher {
+			return newMatcher(StreamFilterParams{Locations: []string{"-122.75", "36.8", "-121.75", "37.8", "-74", "40", "-73", "41"}})
+		}
+
+		It("should match coordinates inside a box", func() {
+			match := m().Match(&Tweet{Coordinates: &Coordinates{Coordinates: [2]float64{-73.5, 40.5}}})
+			Ω(match.Locations).Should(Equal([]int{1}))
+			Ω(m().Matches(&Tweet{Coordinates: &Coordinates{Coordinates: [2]float64{0, 0}}})).Should(BeFalse())
+		})
+
+		It("should match places intersecting a box when there are no coordinates", func() {
+			place := &Place{BoundingBox: BoundingBox{Coordinates: [][][2]float64{{
+				{-123, 37}, {-123, 38}, {-122, 38}, {-122, 37},
+			}}}}
+			Ω(m().Match(&Tweet{Place: place}).Locations).Should(Equal([]int{0}))
+		})
+
+		It("should reject invalid locations", func() {
+			_, err := NewFilterMatcher(StreamFilterParams{Locations: []string{"1", "2", "3"}})
+			Ω(err).Should(HaveOccurred())
+			_, err = NewFilterMatcher(StreamFilterParams{Locations: []string{"1", "2", "3", "x"}})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	It("should require a matching language and filter level", func() {
+		m := newMatcher(StreamFilterParams{Track: []string{"go"}, Language: []string{"en"}, FilterLevel: "low"})
+		Ω(m.Matches(&Tweet{Text: "go", Lang: "en", FilterLevel: "medium"})).Should(BeTrue())
+		Ω(m.Matches(&Tweet{Text: "go", Lang: "fr"})).Should(BeFalse())
+		Ω(m.Matches(&Tweet{Text: "go", Lang: "en", FilterLevel: "none"})).Should(BeFalse())
+	})
+})
@@ -0,0 +1,273 @@
+package twitter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRecordPrefix  = "stream"
+	defaultRecordMaxSize = 100 << 20
+	recordExt            = ".log"
+	recordTimeFormat     = "20060102T150405.000000000"
+)
+
+// StreamRecorderParams represents the options for recording stream traffic.
+type StreamRecorderParams struct {
+	// Dir is the directory that files are written to. It is created if it
+	// does not exist.
+	Dir string
+	// Prefix is the prefix of each file name. The default is "stream".
+	Prefix string
+	// MaxSize is the size in bytes after which a new file is started. The
+	// default is 100MB.
+	MaxSize int64
+	// MaxAge is the duration after which a new file is started. Zero means
+	// files are only rotated by size.
+	MaxAge time.Duration
+}
+
+// StreamRecorder writes raw stream lines to rotating files in a directory, so
+// that they can be replayed later with NewReplayStream. Each line is written as
+// the time it was received in RFC 3339 format, a tab, and the raw line
+// (empty for keep-alives), terminated by "\r\n". Files are named with the
+// prefix and the UTC time they were started, so they sort in order. A
+// StreamRecorder is safe for concurrent use.
+type StreamRecorder struct {
+	params StreamRecorderParams
+
+	mu     sync.Mutex
+	file   *os.File
+	w      *bufio.Writer
+	size   int64
+	opened time.Time
+	err    error
+}
+
+// NewStreamRecorder returns a new StreamRecorder with the provided parameters.
+func NewStreamRecorder(params StreamRecorderParams) (*StreamRecorder, error) {
+	if params.Prefix == "" {
+		params.Prefix = defaultRecordPrefix
+	}
+	if params.MaxSize <= 0 {
+		params.MaxSize = defaultRecordMaxSize
+	}
+	if err := os.MkdirAll(params.Dir, 0755); err != nil {
+		return nil, err
+	}
+	return &StreamRecorder{params: params}, nil
+}
+
+// WithStreamRecorder returns a new shallow copy of the Client whose streams
+// write every line they receive to the provided StreamRecorder.
+func (c *Client) WithStreamRecorder(r *StreamRecorder) *Client {
+	newC := *c
+	newC.streamOpts.recorder = r
+	return &newC
+}
+
+// Record writes a raw stream line received at the provided time. After an
+// error, Record stops writing and returns the error.
+func (r *StreamRecorder) Record(t time.Time, line []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	if err := r.rotate(t); err != nil {
+		r.err = err
+		return err
+	}
+	n, err := fmt.Fprintf(r.w, "%s\t%s\r\n", t.Format(time.RFC3339Nano), line)
+	r.size += int64(n)
+	if err != nil {
+		r.err = err
+	}
+	return err
+}
+
+// Err returns the error that stopped recording, if any.
+func (r *StreamRecorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+// Flush writes any buffered lines to the current file.
+func (r *StreamRecorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.w == nil {
+		return nil
+	}
+	return r.w.Flush()
+}
+
+// Close flushes and closes the current file. A later call to Record starts a
+// new file.
+func (r *StreamRecorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.closeFile()
+}
+
+// rotate starts a new file if there is none or the current one is too large
+// or too old.
+func (r *StreamRecorder) rotate(t time.Time) error {
+	if r.file != nil && r.size < r.params.MaxSize &&
+		(r.params.MaxAge <= 0 || t.Sub(r.opened) < r.params.MaxAge) {
+		return nil
+	}
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+	name := r.params.Prefix + "-" + t.UTC().Format(recordTimeFormat) + recordExt
+	f, err := os.OpenFile(filepath.Join(r.params.Dir, name), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	r.file = f
+	r.w = bufio.NewWriter(f)
+	r.size = 0
+	r.opened = t
+	return nil
+}
+
+func (r *StreamRecorder) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.w.Flush()
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	r.file, r.w = nil, nil
+	return err
+}
+
+// RecordedFiles returns the files written to dir by a StreamRecorder with the
+// provided prefix, oldest first. An empty prefix uses the default.
+func RecordedFiles(dir, prefix string) ([]string, error) {
+	if prefix == "" {
+		prefix = defaultRecordPrefix
+	}
+	files, err := filepath.Glob(filepath.Join(dir, prefix+"-*"+recordExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// ReplayParams represents the options for replaying recorded stream traffic.
+type ReplayParams struct {
+	// Files are the recorded files, replayed in order.
+	Files []string
+	// Speed is the playback rate relative to the recorded timing, such as 1
+	// for the original speed or 10 for ten times faster. Zero replays as fast
+	// as possible.
+	Speed float64
+}
+
+// NewReplayStream returns a new Stream that replays recorded stream traffic
+// through the same decoding as a live stream. The stream closes with io.EOF
+// once every file has been replayed, or with the first error encountered.
+func NewReplayStream(ctx context.Context, params ReplayParams) *Stream {
+	errFn := func(_ Backoff, err error) error {
+		return err
+	}
+	return newStream(ctx, &replayClient{params: params}, "GET", "", nil, errFn, streamOptions{})
+}
+
+// replayClient implements the oauthClient interface, responding with a body
+// that writes the recorded lines.
+type replayClient struct {
+	params  ReplayParams
+	started bool
+}
+
+var errReplayReconnect = errors.New("twitter: replay cannot reconnect")
+
+func (rc *replayClient) do(ctx context.Context, method, urlStr string, values url.Values) (*http.Response, error) {
+	if rc.started {
+		return nil, errReplayReconnect
+	}
+	rc.started = true
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(replayFiles(ctx, pw, rc.params.Files, rc.params.Speed))
+	}()
+	return &http.Response{
+		StatusCode: 200,
+		Body:       pr,
+	}, nil
+}
+
+// replayFiles writes the raw lines of the files to w, waiting between lines
+// according to their recorded times divided by speed.
+func replayFiles(ctx context.Context, w io.Writer, files []string, speed float64) error {
+	var first time.Time
+	start := time.Now()
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(nil, 1<<20)
+		scanner.Split(scanLines)
+		for scanner.Scan() {
+			t, line, err := parseRecordedLine(scanner.Bytes())
+			if err != nil {
+				f.Close()
+				return fmt.Errorf("%s: %v", name, err)
+			}
+			if first.IsZero() {
+				first = t
+			}
+			if speed > 0 {
+				at := start.Add(time.Duration(float64(t.Sub(first)) / speed))
+				select {
+				case <-ctx.Done():
+					f.Close()
+					return ctx.Err()
+				case <-time.After(time.Until(at)):
+				}
+			}
+			if _, err = w.Write(append(line, '\r', '\n')); err != nil {
+				f.Close()
+				return err
+			}
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseRecordedLine(b []byte) (time.Time, []byte, error) {
+	i := bytes.IndexByte(b, '\t')
+	if i < 0 {
+		return time.Time{}, nil, errors.New("invalid recorded line")
+	}
+	t, err := time.Parse(time.RFC3339Nano, string(b[:i]))
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	return t, append([]byte(nil), b[i+1:]...), nil
+}
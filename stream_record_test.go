@@ -0,0 +1,76 @@
+package twitter
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StreamRecorder", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "twitter-record")
+		Ω(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should record a stream to rotating files and replay it", func() {
+		r, err := NewStreamRecorder(StreamRecorderParams{Dir: dir, MaxSize: 1})
+		Ω(err).ShouldNot(HaveOccurred())
+		m := newStreamMock(`{"id_str":"1"}` + "\r\n\r\n" + `{"id_str":"2"}` + "\r\n")
+		s := newStream(context.Background(), m, "GET", "", nil, nil, streamOptions{recorder: r})
+		Ω((<-s.Messages()).IDStr).Should(Equal("1"))
+		Ω((<-s.Messages()).IDStr).Should(Equal("2"))
+		s.Close()
+		Ω(r.Close()).ShouldNot(HaveOccurred())
+
+		files, err := RecordedFiles(dir, "")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(files).Should(HaveLen(3))
+
+		replay := NewReplayStream(context.Background(), ReplayParams{Files: files})
+		Ω((<-replay.Messages()).IDStr).Should(Equal("1"))
+		Ω((<-replay.Messages()).IDStr).Should(Equal("2"))
+		Eventually(replay.Done()).Should(BeClosed())
+		Ω(replay.Err()).Should(Equal(io.EOF))
+	})
+
+	It("should replay with the recorded timing scaled by speed", func() {
+		r, err := NewStreamRecorder(StreamRecorderParams{Dir: dir})
+		Ω(err).ShouldNot(HaveOccurred())
+		t := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+		Ω(r.Record(t, []byte(`{"id_str":"1"}`))).ShouldNot(HaveOccurred())
+		Ω(r.Record(t.Add(time.Second), []byte(`{"id_str":"2"}`))).ShouldNot(HaveOccurred())
+		Ω(r.Close()).ShouldNot(HaveOccurred())
+
+		files, err := RecordedFiles(dir, "")
+		Ω(err).ShouldNot(HaveOccurred())
+		Ω(files).Should(HaveLen(1))
+
+		start := time.Now()
+		replay := NewReplayStream(context.Background(), ReplayParams{Files: files, Speed: 10})
+		Ω((<-replay.Messages()).IDStr).Should(Equal("1"))
+		Ω((<-replay.Messages()).IDStr).Should(Equal("2"))
+		Ω(time.Since(start)).Should(BeNumerically(">=", 100*time.Millisecond))
+		Eventually(replay.Done()).Should(BeClosed())
+		Ω(replay.Err()).Should(Equal(io.EOF))
+	})
+
+	It("should close a replay with an error on invalid files", func() {
+		name := dir + "/stream-bad.log"
+		Ω(ioutil.WriteFile(name, []byte("not recorded\r\n"), 0644)).ShouldNot(HaveOccurred())
+		replay := NewReplayStream(context.Background(), ReplayParams{Files: []string{name}})
+		Eventually(replay.Done()).Should(BeClosed())
+		Ω(replay.Err()).Should(MatchError(ContainSubstring("invalid recorded line")))
+	})
+})
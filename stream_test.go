@@ -0,0 +1,404 @@
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// streamMock implements the oauthClient interface, responding to each stream
+// request with the next status and body from statuses and bodies. The body
+// stays open until the request's context is cancelled. If conns is set, each
+// body is also sent on it so that more lines can be written.
+type streamMock struct {
+	statuses []int
+	bodies   []string
+	requests chan *http.Request
+	conns    chan io.Writer
+}
+
+func (m *streamMock) do(ctx context.Context, method, urlStr string, values url.Values) (*http.Response, error) {
+	req, _ := http.NewRequest(method, urlStr+"?"+values.Encode(), nil)
+	m.requests <- req
+	status := 200
+	if len(m.statuses) > 0 {
+		status, m.statuses = m.statuses[0], m.statuses[1:]
+	}
+	body := ""
+	if len(m.bodies) > 0 {
+		body, m.bodies = m.bodies[0], m.bodies[1:]
+	}
+	pr, pw := io.Pipe()
+	if m.conns != nil {
+		m.conns <- pw
+	}
+	go func() {
+		io.WriteString(pw, body)
+		<-ctx.Done()
+		pw.CloseWithError(ctx.Err())
+	}()
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       pr,
+	}, nil
+}
+
+func newStreamMock(bodies ...string) *streamMock {
+	return &streamMock{
+		bodies:   bodies,
+		requests: make(chan *http.Request, 10),
+	}
+}
+
+var _ = Describe("Stream", func() {
+	Context("newStream", func() {
+		It("should request the endpoint and decode messages", func() {
+			m := newStreamMock(`{"id_str":"1","text":"hello"}` + "\r\n\r\n" + `{"delete":{"status":{"id_str":"2"}}}` + "\r\n")
+			values := parseSampleParams(StreamSampleParams{
+				Language:      []string{"en", "fr"},
+				StallWarnings: true,
+			})
+			s := newStream(context.Background(), m, "GET", "https://stream.twitter.com/1.1/statuses/sample.json", values, nil, streamOptions{})
+
+			req := <-m.requests
+			Ω(req.Method).Should(Equal("GET"))
+			Ω(req.URL.Path).Should(Equal("/1.1/statuses/sample.json"))
+			Ω(req.URL.Query().Get("language")).Should(Equal("en,fr"))
+			Ω(req.URL.Query().Get("stall_warnings")).Should(Equal("true"))
+
+			msg := <-s.Messages()
+			Ω(msg.Tweet).ShouldNot(BeNil())
+			Ω(msg.Text).Should(Equal("hello"))
+			msg = <-s.Messages()
+			Ω(msg.Delete).ShouldNot(BeNil())
+			Ω(msg.Delete.Status.IDStr).Should(Equal("2"))
+
+			Ω(s.Close()).Should(Equal(context.Canceled))
+		})
+	})
+
+	Context("WithStreamBackoff", func() {
+		It("should use the provided BackoffPolicy for reconnects", func() {
+			m := newStreamMock()
+			m.statuses = []int{503, 503}
+			c := (&Client{}).WithStreamBackoff(func() BackoffPolicy {
+				return NewBackoff(BackoffParams{HTTPInitDelay: time.Millisecond})
+			})
+			var waits []time.Duration
+			s := newStream(context.Background(), m, "GET", "https://stream.twitter.com/1.1/statuses/sample.json", nil, func(boff Backoff, err error) error {
+				waits = append(waits, boff.NextWait())
+				return nil
+			}, c.streamOpts)
+
+			for i := 0; i < 3; i++ {
+				<-m.requests
+			}
+			s.Close()
+			Ω(waits[:2]).Should(Equal([]time.Duration{time.Millisecond, 2 * time.Millisecond}))
+		})
+	})
+
+	Context("UpdateFilter", func() {
+		It("should keep the current connection until the new one delivers a message", func() {
+			m := newStreamMock()
+			m.conns = make(chan io.Writer, 10)
+			values := parseFilterParams(StreamFilterParams{Track: []string{"go"}})
+			s := newStream(context.Background(), m, "POST", "https://stream.twitter.com/1.1/statuses/filter.json", values, nil, streamOptions{})
+			<-m.requests
+			current := <-m.conns
+			io.WriteString(current, `{"id_str":"1"}`+"\r\n")
+			Ω((<-s.Messages()).IDStr).Should(Equal("1"))
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- s.UpdateFilter(StreamFilterParams{Track: []string{"golang"}})
+			}()
+			req := <-m.requests
+			Ω(req.URL.Query().Get("track")).Should(Equal("golang"))
+			next := <-m.conns
+
+			// A keep-alive on the new connection does not switch to it.
+			io.WriteString(next, "\r\n")
+			io.WriteString(current, `{"id_str":"2"}`+"\r\n")
+			Ω((<-s.Messages()).IDStr).Should(Equal("2"))
+			Ω(errs).ShouldNot(Receive())
+
+			io.WriteString(next, `{"id_str":"2"}`+"\r\n")
+			Eventually(errs).Should(Receive(BeNil()))
+			io.WriteString(next, `{"id_str":"3"}`+"\r\n")
+			Ω((<-s.Messages()).IDStr).Should(Equal("3"))
+			Ω(s.Close()).Should(Equal(context.Canceled))
+		})
+
+		It("should switch after the grace period and then stop deduplicating", func() {
+			m := newStreamMock()
+			m.conns = make(chan io.Writer, 10)
+			s := newStream(context.Background(), m, "POST", "https://stream.twitter.com/1.1/statuses/filter.json", nil, nil, streamOptions{
+				updateGrace: 20 * time.Millisecond,
+			})
+			<-m.requests
+			<-m.conns
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- s.UpdateFilter(StreamFilterParams{Track: []string{"golang"}})
+			}()
+			<-m.requests
+			next := <-m.conns
+			io.WriteString(next, "\r\n")
+			time.Sleep(30 * time.Millisecond)
+			io.WriteString(next, "\r\n")
+			Eventually(errs).Should(Receive(BeNil()))
+
+			Eventually(func() int32 { return atomic.LoadInt32(&s.dedupeOn) }).Should(BeZero())
+			for i := 0; i < 2; i++ {
+				io.WriteString(next, `{"id_str":"1"}`+"\r\n")
+				Ω((<-s.Messages()).IDStr).Should(Equal("1"))
+			}
+			Ω(s.Close()).Should(Equal(context.Canceled))
+		})
+
+		It("should switch to the new filter while waiting to reconnect", func() {
+			m := newStreamMock()
+			m.statuses = []int{503, 200}
+			m.conns = make(chan io.Writer, 10)
+			s := newStream(context.Background(), m, "POST", "https://stream.twitter.com/1.1/statuses/filter.json", nil, nil, streamOptions{})
+			<-m.requests
+			<-m.conns
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- s.UpdateFilter(StreamFilterParams{Track: []string{"golang"}})
+			}()
+			<-m.requests
+			next := <-m.conns
+			io.WriteString(next, `{"id_str":"1"}`+"\r\n")
+			Eventually(errs).Should(Receive(BeNil()))
+
+			var msg StreamMessage
+			Eventually(s.Messages()).Should(Receive(&msg))
+			Ω(msg.IDStr).Should(Equal("1"))
+			Consistently(m.requests).ShouldNot(Receive())
+			Ω(s.Close()).Should(Equal(context.Canceled))
+		})
+
+		It("should switch to the new filter when updated while connecting", func() {
+			var mu sync.Mutex
+			var tracks []string
+			release := make(chan struct{})
+			m := streamFuncMock(func(ctx context.Context, values url.Values) (int, string) {
+				mu.Lock()
+				tracks = append(tracks, values.Get("track"))
+				mu.Unlock()
+				if values.Get("track") == "go" {
+					<-release
+					return 200, `{"id_str":"1"}` + "\r\n"
+				}
+				return 200, `{"id_str":"2"}` + "\r\n"
+			})
+			values := parseFilterParams(StreamFilterParams{Track: []string{"go"}})
+			s := newStream(context.Background(), m, "POST", "https://stream.twitter.com/1.1/statuses/filter.json", values, nil, streamOptions{})
+			Eventually(func() int {
+				mu.Lock()
+				defer mu.Unlock()
+				return len(tracks)
+			}).Should(Equal(1))
+
+			Ω(s.UpdateFilter(StreamFilterParams{Track: []string{"golang"}})).Should(Succeed())
+			close(release)
+			Ω((<-s.Messages()).IDStr).Should(Equal("2"))
+			mu.Lock()
+			Ω(tracks).Should(Equal([]string{"go", "golang"}))
+			mu.Unlock()
+			Ω(s.Close()).Should(Equal(context.Canceled))
+		})
+
+		It("should reject streams that do not accept filter parameters", func() {
+			m := newStreamMock(`{"id_str":"1"}` + "\r\n")
+			s := newStream(context.Background(), m, "GET", "https://stream.twitter.com/1.1/statuses/sample.json", nil, nil, streamOptions{})
+			<-m.requests
+			Ω((<-s.Messages()).IDStr).Should(Equal("1"))
+
+			err := s.UpdateFilter(StreamFilterParams{Track: []string{"golang"}})
+			Ω(err).Should(Equal(ErrNotFilterStream))
+			Consistently(m.requests).ShouldNot(Receive())
+			Ω(s.Close()).Should(Equal(context.Canceled))
+		})
+
+		It("should keep the current connection when the new one fails", func() {
+			m := newStreamMock(`{"id_str":"1"}` + "\r\n")
+			m.statuses = []int{200, 406}
+			s := newStream(context.Background(), m, "POST", "https://stream.twitter.com/1.1/statuses/filter.json", nil, nil, streamOptions{})
+			<-m.requests
+			Ω((<-s.Messages()).IDStr).Should(Equal("1"))
+
+			err := s.UpdateFilter(StreamFilterParams{Track: []string{"golang"}})
+			Ω(err).Should(MatchError("406: Not Acceptable"))
+			<-m.requests
+			Consistently(s.Done()).ShouldNot(BeClosed())
+			Ω(s.Close()).Should(Equal(context.Canceled))
+		})
+	})
+
+	Context("WithStreamCallbacks", func() {
+		It("should call the lifecycle callbacks", func() {
+			var mu sync.Mutex
+			var events []string
+			record := func(event string) {
+				mu.Lock()
+				events = append(events, event)
+				mu.Unlock()
+			}
+			opts := streamOptions{
+				stallTimeout: 50 * time.Millisecond,
+				callbacks: StreamCallbacks{
+					OnConnect: func(header http.Header) {
+						record("connect " + header.Get("Content-Type"))
+					},
+					OnFirstMessage: func() { record("first") },
+					OnStall:        func() { record("stall") },
+					OnDisconnect: func(msg DisconnectMessage) {
+						record("disconnect " + strconv.Itoa(msg.Code))
+					},
+					OnClose: func(err error) { record("close " + err.Error()) },
+				},
+			}
+			errFn := func(b Backoff, err error) error {
+				return err
+			}
+			m := newStreamMock("\r\n" + `{"id_str":"1"}` + "\r\n" + `{"disconnect_message":{"code":12}}` + "\r\n")
+			s := newStream(context.Background(), m, "GET", "", nil, errFn, opts)
+			<-s.Messages()
+			<-s.Messages()
+			Eventually(s.Done()).Should(BeClosed())
+			Ω(s.Err()).Should(Equal(ErrStreamStalled))
+			Ω(events).Should(Equal([]string{
+				"connect application/json",
+				"first",
+				"disconnect 12",
+				"stall",
+				"close " + ErrStreamStalled.Error(),
+			}))
+		})
+	})
+
+	Context("bad lines", func() {
+		It("should report and skip lines that cannot be decoded", func() {
+			var bad []string
+			opts := streamOptions{callbacks: StreamCallbacks{
+				OnBadLine: func(line []byte, err error) {
+					bad = append(bad, string(line))
+				},
+			}}
+			m := newStreamMock(`{"id_str":"1"}` + "\r\n" + "oops\r\n" + `{"id_str":"2"}` + "\r\n")
+			s := newStream(context.Background(), m, "GET", "", nil, nil, opts)
+			msg := <-s.Messages()
+			Ω(string(msg.Raw)).Should(Equal(`{"id_str":"1"}`))
+			Ω((<-s.Messages()).IDStr).Should(Equal("2"))
+			Ω(bad).Should(Equal([]string{"oops"}))
+			Ω(m.requests).Should(HaveLen(1))
+			s.Close()
+		})
+
+		It("should recycle the connection after the limit", func() {
+			var errs []error
+			errFn := func(b Backoff, err error) error {
+				errs = append(errs, err)
+				return err
+			}
+			m := newStreamMock("bad\r\nworse\r\n")
+			s := newStream(context.Background(), m, "GET", "", nil, errFn, streamOptions{badLineLimit: 2})
+			Eventually(s.Done()).Should(BeClosed())
+			Ω(s.Err()).Should(Equal(ErrTooManyBadLines))
+			Ω(errs).Should(Equal([]error{ErrTooManyBadLines}))
+		})
+	})
+
+	Context("StreamMessage", func() {
+		decode := func(s string) StreamMessage {
+			var sm StreamMessage
+			Ω(json.Unmarshal([]byte(s), &sm)).Should(Succeed())
+			return sm
+		}
+
+		It("should decode Tweets", func() {
+			sm := decode(`{"id_str":"1","text":"hello","source":"<a>web</a>","user":{"screen_name":"someone"}}`)
+			Ω(sm.Kind()).Should(Equal(KindTweet))
+			Ω(sm.Source).Should(Equal("<a>web</a>"))
+			Ω(sm.User.ScreenName).Should(Equal("someone"))
+		})
+
+		It("should decode events", func() {
+			sm := decode(`{"event":"favorite","created_at":"Sat Sep 04 16:10:54 +0000 2010",
+				"source":{"screen_name":"source"},"target":{"screen_name":"target"},
+				"target_object":{"id_str":"1","text":"hello"}}`)
+			Ω(sm.Kind()).Should(Equal(KindEvent))
+			Ω(sm.Tweet).Should(BeNil())
+			Ω(sm.Event.Event).Should(Equal("favorite"))
+			Ω(sm.Event.CreatedAt).Should(Equal("Sat Sep 04 16:10:54 +0000 2010"))
+			Ω(sm.Event.Source.ScreenName).Should(Equal("source"))
+			Ω(sm.Event.Target.ScreenName).Should(Equal("target"))
+			t, err := sm.Event.TargetTweet()
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(t.Text).Should(Equal("hello"))
+		})
+
+		It("should decode control messages", func() {
+			for msg, kind := range map[string]MessageKind{
+				`{"warning":{"code":"FALLING_BEHIND","message":"behind","percent_full":60}}`: KindWarning,
+				`{"friends":[1,2]}`:                                 KindFriends,
+				`{"friends_str":["1","2"]}`:                         KindFriends,
+				`{"control":{"control_uri":"/1.1/site/c/1_1_abc"}}`: KindControl,
+				`{"delete":{"status":{"id_str":"1"}}}`:              KindDelete,
+				`{"scrub_geo":{"user_id_str":"1"}}`:                 KindScrubGeo,
+				`{"limit":{"track":10}}`:                            KindLimit,
+				`{"status_withheld":{"id":1}}`:                      KindStatusWithheld,
+				`{"user_withheld":{"id":1}}`:                        KindUserWithheld,
+				`{"disconnect_message":{"code":7}}`:                 KindDisconnect,
+				`{}`:                                                KindUnknown,
+			} {
+				Ω(decode(msg).Kind()).Should(Equal(kind), msg)
+			}
+			sm := decode(`{"warning":{"code":"FALLING_BEHIND","message":"behind","percent_full":60}}`)
+			Ω(sm.Warning.PercentFull).Should(Equal(60))
+			Ω(KindWarning.String()).Should(Equal("warning"))
+		})
+	})
+
+	Context("scanLines", func() {
+		It("should split messages on \\r\\n", func() {
+			adv, tok, err := scanLines([]byte("abc\r\ndef"), false)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(adv).Should(Equal(5))
+			Ω(string(tok)).Should(Equal("abc"))
+
+			adv, tok, err = scanLines([]byte("def"), false)
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(adv).Should(Equal(0))
+			Ω(tok).Should(BeNil())
+		})
+	})
+
+	Context("parseFilterParams", func() {
+		It("should comma separate filters", func() {
+			values := parseFilterParams(StreamFilterParams{
+				Track:  []string{"a b", "c"},
+				Follow: []string{"1", "2"},
+			})
+			Ω(values.Get("track")).Should(Equal("a b,c"))
+			Ω(values.Get("follow")).Should(Equal("1,2"))
+			Ω(strings.Contains(values.Encode(), "stall_warnings")).Should(BeFalse())
+		})
+	})
+})
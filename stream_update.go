@@ -0,0 +1,173 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// dedupeSize is the number of recent Tweet IDs remembered, so that Tweets
+// received on both connections while UpdateFilter switches are only sent once.
+const dedupeSize = 10000
+
+// updateGracePeriod is how long UpdateFilter waits for a message on the new
+// connection before switching to it anyway, and how long Tweets are
+// deduplicated after the switch.
+const updateGracePeriod = 30 * time.Second
+
+// ErrNotFilterStream is returned by UpdateFilter when the stream was not
+// started with a POST request, such as a sample stream.
+var ErrNotFilterStream = errors.New("twitter: stream does not accept filter parameters")
+
+// UpdateFilter changes the filter parameters of a running filter stream without
+// dropping Tweets. A second connection is opened with the new parameters while
+// the current connection keeps delivering messages. Once the new connection
+// receives its first message (or, if it only sends keep-alives, once 30 seconds
+// have passed) it replaces the current connection. Tweets received on both
+// connections around the switch are only sent once. UpdateFilter blocks until
+// the switch happens, the new connection fails, or the stream is closed; on
+// failure the current connection is kept.
+func (s *Stream) UpdateFilter(params StreamFilterParams) error {
+	if s.method != "POST" {
+		return ErrNotFilterStream
+	}
+	return s.update(parseFilterParams(params))
+}
+
+func (s *Stream) update(values url.Values) error {
+	s.updateMu.Lock()
+	defer s.updateMu.Unlock()
+
+	grace := s.opts.updateGrace
+	if grace <= 0 {
+		grace = updateGracePeriod
+	}
+	gen := s.startDedupe()
+	switched := false
+	defer func() {
+		if !switched {
+			s.stopDedupe(gen)
+		}
+	}()
+
+	// Open the new connection and wait for it to start sending messages.
+	ctx, cancel := context.WithCancel(s.ctx)
+	resp, err := s.client.do(ctx, s.method, s.endpoint, values)
+	if err != nil {
+		cancel()
+		return err
+	}
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		cancel()
+		return fmt.Errorf("%d: %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	conn := newStreamConn(resp, cancel, values, s.opts.stallTimeout)
+	deadline := time.Now().Add(grace)
+	for {
+		b, err := conn.scan()
+		if err != nil {
+			conn.close()
+			return err
+		}
+		conn.pending = append(conn.pending, append([]byte(nil), b...))
+		if !isKeepAlive(b) || time.Now().After(deadline) {
+			break
+		}
+	}
+
+	// Hand the new connection to the stream and close the current one.
+	s.mu.Lock()
+	if err = s.ctx.Err(); err != nil {
+		s.mu.Unlock()
+		conn.close()
+		return err
+	}
+	if s.next != nil {
+		s.next.close()
+	}
+	s.next = conn
+	current := s.current
+	s.mu.Unlock()
+	if current != nil {
+		current.cancel()
+	} else {
+		// Wake the stream if it is waiting to reconnect.
+		select {
+		case s.chNext <- struct{}{}:
+		default:
+		}
+	}
+	switched = true
+	time.AfterFunc(grace, func() { s.stopDedupe(gen) })
+	return nil
+}
+
+// startDedupe starts deduplicating Tweets for a switch and returns its
+// generation.
+func (s *Stream) startDedupe() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dedupeGen++
+	atomic.StoreInt32(&s.dedupeOn, 1)
+	return s.dedupeGen
+}
+
+// stopDedupe stops deduplicating Tweets and releases the remembered IDs,
+// unless a later switch has started.
+func (s *Stream) stopDedupe(gen int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dedupeGen != gen {
+		return
+	}
+	atomic.StoreInt32(&s.dedupeOn, 0)
+	s.dedupe.reset()
+}
+
+// isDuplicate reports whether the Tweet was already sent during the current
+// switch. Outside of a switch, it does nothing.
+func (s *Stream) isDuplicate(sm *StreamMessage) bool {
+	if atomic.LoadInt32(&s.dedupeOn) == 0 || sm.Tweet == nil || sm.IDStr == "" {
+		return false
+	}
+	return s.dedupe.seen(sm.IDStr)
+}
+
+// tweetDeduper remembers the IDs of the most recently sent Tweets.
+type tweetDeduper struct {
+	mu   sync.Mutex
+	ring []string
+	pos  int
+	ids  map[string]struct{}
+}
+
+// seen records the Tweet ID and reports whether it was already recorded.
+func (d *tweetDeduper) seen(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.ids[id]; ok {
+		return true
+	}
+	if d.ring == nil {
+		d.ring = make([]string, dedupeSize)
+		d.ids = make(map[string]struct{}, dedupeSize)
+	}
+	delete(d.ids, d.ring[d.pos])
+	d.ring[d.pos] = id
+	d.ids[id] = struct{}{}
+	d.pos = (d.pos + 1) % len(d.ring)
+	return false
+}
+
+// reset forgets every recorded ID.
+func (d *tweetDeduper) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.ring, d.ids, d.pos = nil, nil, 0
+}
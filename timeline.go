@@ -0,0 +1,290 @@
+package twitter
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimelinePageFn represents a function that fetches a page of Tweets newer than
+// sinceID and no newer than maxID. Either ID may be empty.
+type TimelinePageFn func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error)
+
+// TimelineParams represents the options for walking a timeline.
+type TimelineParams struct {
+	// SinceID stops walking backwards at (and excludes) this Tweet ID. When
+	// polling, it is the checkpoint that only newer Tweets are emitted after.
+	SinceID string
+	// MaxID starts walking backwards at (and includes) this Tweet ID.
+	MaxID string
+	// MaxPages limits the number of pages requested when walking backwards.
+	// Zero means no limit.
+	MaxPages int
+	// MaxTweets limits the number of Tweets returned when walking backwards.
+	// Zero means no limit.
+	MaxTweets int
+}
+
+// TimelineWalker pages through a max_id/since_id timeline such as
+// UserTimeline, HomeTimeline, MentionsTimeline or SearchTweets. It can walk
+// backwards from the newest Tweet until the timeline is exhausted, or poll
+// forwards from a since_id checkpoint. A TimelineWalker is not safe for
+// concurrent use.
+type TimelineWalker struct {
+	fn        TimelinePageFn
+	sinceID   string
+	maxID     string
+	maxPages  int
+	maxTweets int
+	pages     int
+	tweets    int
+	done      bool
+	seen      map[string]bool // IDs in the previous page
+}
+
+// NewTimelineWalker returns a new TimelineWalker that fetches pages using the
+// provided function.
+func NewTimelineWalker(fn TimelinePageFn, params TimelineParams) *TimelineWalker {
+	return &TimelineWalker{
+		fn:        fn,
+		sinceID:   params.SinceID,
+		maxID:     params.MaxID,
+		maxPages:  params.MaxPages,
+		maxTweets: params.MaxTweets,
+	}
+}
+
+// UserTimelineWalker returns a TimelineWalker over the Twitter
+// /statuses/user_timeline.json endpoint.
+func (c *Client) UserTimelineWalker(params UserTimelineParams, timelineParams TimelineParams) *TimelineWalker {
+	return NewTimelineWalker(func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error) {
+		params.SinceID, params.MaxID = sinceID, maxID
+		return c.UserTimeline(ctx, params)
+	}, timelineParams)
+}
+
+// HomeTimelineWalker returns a TimelineWalker over the Twitter
+// /statuses/home_timeline.json endpoint.
+func (c *Client) HomeTimelineWalker(params HomeTimelineParams, timelineParams TimelineParams) *TimelineWalker {
+	return NewTimelineWalker(func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error) {
+		params.SinceID, params.MaxID = sinceID, maxID
+		return c.HomeTimeline(ctx, params)
+	}, timelineParams)
+}
+
+// MentionsTimelineWalker returns a TimelineWalker over the Twitter
+// /statuses/mentions_timeline.json endpoint.
+func (c *Client) MentionsTimelineWalker(params MentionsTimelineParams, timelineParams TimelineParams) *TimelineWalker {
+	return NewTimelineWalker(func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error) {
+		params.SinceID, params.MaxID = sinceID, maxID
+		return c.MentionsTimeline(ctx, params)
+	}, timelineParams)
+}
+
+// ListStatusesWalker returns a TimelineWalker over the Twitter
+// /lists/statuses.json endpoint.
+func (c *Client) ListStatusesWalker(params ListStatusesParams, timelineParams TimelineParams) *TimelineWalker {
+	return NewTimelineWalker(func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error) {
+		params.SinceID, params.MaxID = sinceID, maxID
+		return c.ListStatuses(ctx, params)
+	}, timelineParams)
+}
+
+// SearchTweetsWalker returns a TimelineWalker over the Twitter
+// /search/tweets.json endpoint.
+func (c *Client) SearchTweetsWalker(params SearchTweetsParams, timelineParams TimelineParams) *TimelineWalker {
+	return NewTimelineWalker(func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error) {
+		params.SinceID, params.MaxID = sinceID, maxID
+		return c.SearchTweets(ctx, params)
+	}, timelineParams)
+}
+
+// MaxID returns the max_id of the next page to be fetched when walking
+// backwards, which can be saved to resume walking later.
+func (w *TimelineWalker) MaxID() string {
+	return w.maxID
+}
+
+// SinceID returns the since_id checkpoint. When polling, it is the ID of the
+// newest Tweet emitted so far, which can be saved to resume polling later.
+func (w *TimelineWalker) SinceID() string {
+	return w.sinceID
+}
+
+// Done returns whether walking backwards has reached the end of the timeline
+// or a limit.
+func (w *TimelineWalker) Done() bool {
+	return w.done
+}
+
+// Next fetches the next (older) page of Tweets, in the order returned by the
+// endpoint. A page cut short by MaxTweets keeps its newest Tweets, newest
+// first. It returns an empty slice and no error once walking is done.
+func (w *TimelineWalker) Next(ctx context.Context) ([]Tweet, error) {
+	for !w.done {
+		if w.maxPages > 0 && w.pages >= w.maxPages {
+			w.done = true
+			return nil, nil
+		}
+		tweets, minID, err := w.page(ctx, w.sinceID, w.maxID)
+		if err != nil {
+			return nil, err
+		}
+		w.pages++
+		if minID == "" {
+			w.done = true
+			return nil, nil
+		}
+		w.maxID = decrementID(minID)
+		if len(tweets) == 0 {
+			continue
+		}
+		if w.maxTweets > 0 && w.tweets+len(tweets) >= w.maxTweets {
+			// Keep the newest Tweets, so that resuming from MaxID does not
+			// skip any that were cut from an unordered page.
+			sortTweetsByID(tweets)
+			tweets = tweets[:w.maxTweets-w.tweets]
+			w.maxID = decrementID(tweets[len(tweets)-1].IDStr)
+			w.done = true
+		}
+		w.tweets += len(tweets)
+		return tweets, nil
+	}
+	return nil, nil
+}
+
+// Each calls fn with every Tweet, page by page, until walking is done or fn
+// returns an error.
+func (w *TimelineWalker) Each(ctx context.Context, fn func(Tweet) error) error {
+	for !w.done {
+		tweets, err := w.Next(ctx)
+		if err != nil {
+			return err
+		}
+		for _, t := range tweets {
+			if err = fn(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Poll checks the timeline for Tweets newer than the SinceID checkpoint every
+// interval and calls fn with each new Tweet, oldest first, until the context is
+// cancelled or fn returns an error. If there is no checkpoint, the first check
+// only fetches the newest page. MaxPages and MaxTweets do not apply.
+func (w *TimelineWalker) Poll(ctx context.Context, interval time.Duration, fn func(Tweet) error) error {
+	for {
+		tweets, err := w.poll(ctx)
+		if err != nil {
+			return err
+		}
+		for i := len(tweets) - 1; i >= 0; i-- {
+			if err = fn(tweets[i]); err != nil {
+				return err
+			}
+			w.sinceID = tweets[i].IDStr
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// poll returns every Tweet newer than the checkpoint, newest first.
+func (w *TimelineWalker) poll(ctx context.Context) ([]Tweet, error) {
+	var all []Tweet
+	var maxID string
+	for {
+		tweets, minID, err := w.page(ctx, w.sinceID, maxID)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tweets...)
+		if minID == "" || w.sinceID == "" {
+			// Without a checkpoint, only the newest page is fetched.
+			sortTweetsByID(all)
+			return all, nil
+		}
+		maxID = decrementID(minID)
+	}
+}
+
+// page fetches a page of Tweets within the requested range. Endpoints such as
+// SearchTweets with a mixed or popular result type are not ordered by ID, so
+// Tweets are deduplicated by ID against the rest of the page and the previous
+// page rather than by order. It also returns the lowest ID in the range, from
+// which the next max_id is derived, or "" if there are none.
+func (w *TimelineWalker) page(ctx context.Context, sinceID, maxID string) ([]Tweet, string, error) {
+	res, err := w.fn(ctx, sinceID, maxID)
+	if err != nil {
+		return nil, "", err
+	}
+	tweets := res.Tweets[:0:0]
+	seen := make(map[string]bool, len(res.Tweets))
+	var minID string
+	for _, t := range res.Tweets {
+		if t.IDStr == "" || seen[t.IDStr] {
+			continue
+		}
+		if sinceID != "" && compareIDs(t.IDStr, sinceID) <= 0 {
+			continue
+		}
+		if maxID != "" && compareIDs(t.IDStr, maxID) > 0 {
+			continue
+		}
+		seen[t.IDStr] = true
+		if minID == "" || compareIDs(t.IDStr, minID) < 0 {
+			minID = t.IDStr
+		}
+		if !w.seen[t.IDStr] {
+			tweets = append(tweets, t)
+		}
+	}
+	w.seen = seen
+	return tweets, minID, nil
+}
+
+// sortTweetsByID sorts the Tweets by ID, newest first.
+func sortTweetsByID(tweets []Tweet) {
+	sort.SliceStable(tweets, func(i, j int) bool {
+		return compareIDs(tweets[i].IDStr, tweets[j].IDStr) > 0
+	})
+}
+
+// compareIDs compares two decimal ID strings numerically, returning -1, 0 or 1.
+func compareIDs(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// decrementID returns the decimal ID string minus one, without converting it to
+// a number, so that max_id can be set to exclude the provided ID.
+func decrementID(id string) string {
+	b := []byte(strings.TrimLeft(id, "0"))
+	if len(b) == 0 {
+		return "0"
+	}
+	i := len(b) - 1
+	for ; i >= 0 && b[i] == '0'; i-- {
+		b[i] = '9'
+	}
+	b[i]--
+	s := strings.TrimLeft(string(b), "0")
+	if s == "" {
+		return "0"
+	}
+	return s
+}
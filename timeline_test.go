@@ -0,0 +1,164 @@
+package twitter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Timeline", func() {
+	// fakeTimeline returns up to two Tweets per page from the provided IDs,
+	// which are sorted newest first.
+	fakeTimeline := func(ids *[]string, requests *[][2]string) TimelinePageFn {
+		return func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error) {
+			*requests = append(*requests, [2]string{sinceID, maxID})
+			var tweets []Tweet
+			for _, id := range *ids {
+				if maxID != "" && compareIDs(id, maxID) > 0 {
+					continue
+				}
+				if sinceID != "" && compareIDs(id, sinceID) <= 0 {
+					continue
+				}
+				tweets = append(tweets, Tweet{IDStr: id})
+				if len(tweets) == 2 {
+					break
+				}
+			}
+			return &TweetsResponse{Tweets: tweets}, nil
+		}
+	}
+
+	tweetIDs := func(tweets []Tweet) []string {
+		ids := make([]string, len(tweets))
+		for i, t := range tweets {
+			ids[i] = t.IDStr
+		}
+		return ids
+	}
+
+	Context("decrementID", func() {
+		It("should decrement decimal strings", func() {
+			Ω(decrementID("1000")).Should(Equal("999"))
+			Ω(decrementID("9223372036854775808")).Should(Equal("9223372036854775807"))
+			Ω(decrementID("1")).Should(Equal("0"))
+			Ω(decrementID("0")).Should(Equal("0"))
+		})
+	})
+
+	Context("compareIDs", func() {
+		It("should compare decimal strings numerically", func() {
+			Ω(compareIDs("99", "100")).Should(Equal(-1))
+			Ω(compareIDs("100", "100")).Should(Equal(0))
+			Ω(compareIDs("101", "100")).Should(Equal(1))
+		})
+	})
+
+	Context("Each", func() {
+		It("should walk backwards until since_id", func() {
+			ids := []string{"1000", "900", "800", "700", "600"}
+			var requests [][2]string
+			w := NewTimelineWalker(fakeTimeline(&ids, &requests), TimelineParams{SinceID: "650"})
+			var tweets []Tweet
+			err := w.Each(context.Background(), func(t Tweet) error {
+				tweets = append(tweets, t)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(tweetIDs(tweets)).Should(Equal([]string{"1000", "900", "800", "700"}))
+			Ω(requests).Should(Equal([][2]string{{"650", ""}, {"650", "899"}, {"650", "699"}}))
+		})
+
+		It("should stop at MaxTweets and resume from MaxID", func() {
+			ids := []string{"1000", "900", "800", "700", "600"}
+			var requests [][2]string
+			w := NewTimelineWalker(fakeTimeline(&ids, &requests), TimelineParams{MaxTweets: 3})
+			var tweets []Tweet
+			err := w.Each(context.Background(), func(t Tweet) error {
+				tweets = append(tweets, t)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(tweetIDs(tweets)).Should(Equal([]string{"1000", "900", "800"}))
+			Ω(w.MaxID()).Should(Equal("799"))
+		})
+	})
+
+	Context("Next", func() {
+		It("should keep Tweets that are not ordered by ID", func() {
+			var requests [][2]string
+			w := NewTimelineWalker(func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error) {
+				requests = append(requests, [2]string{sinceID, maxID})
+				switch maxID {
+				case "":
+					return &TweetsResponse{Tweets: []Tweet{{IDStr: "900"}, {IDStr: "1000"}, {IDStr: "900"}, {IDStr: "800"}}}, nil
+				case "799":
+					return &TweetsResponse{Tweets: []Tweet{{IDStr: "600"}, {IDStr: "700"}}}, nil
+				default:
+					return &TweetsResponse{}, nil
+				}
+			}, TimelineParams{})
+			var tweets []Tweet
+			err := w.Each(context.Background(), func(t Tweet) error {
+				tweets = append(tweets, t)
+				return nil
+			})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(tweetIDs(tweets)).Should(Equal([]string{"900", "1000", "800", "600", "700"}))
+			Ω(requests).Should(Equal([][2]string{{"", ""}, {"", "799"}, {"", "599"}}))
+		})
+
+		It("should not skip Tweets cut from an unordered page by MaxTweets", func() {
+			var requests [][2]string
+			fn := func(ctx context.Context, sinceID, maxID string) (*TweetsResponse, error) {
+				requests = append(requests, [2]string{sinceID, maxID})
+				var tweets []Tweet
+				for _, id := range []string{"5", "9", "7"} {
+					if maxID == "" || compareIDs(id, maxID) <= 0 {
+						tweets = append(tweets, Tweet{IDStr: id})
+					}
+				}
+				return &TweetsResponse{Tweets: tweets}, nil
+			}
+			w := NewTimelineWalker(fn, TimelineParams{MaxTweets: 2})
+			tweets, err := w.Next(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(tweetIDs(tweets)).Should(Equal([]string{"9", "7"}))
+			Ω(w.Done()).Should(BeTrue())
+			Ω(w.MaxID()).Should(Equal("6"))
+
+			w = NewTimelineWalker(fn, TimelineParams{MaxID: w.MaxID()})
+			tweets, err = w.Next(context.Background())
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(tweetIDs(tweets)).Should(Equal([]string{"5"}))
+			Ω(requests).Should(Equal([][2]string{{"", ""}, {"", "6"}}))
+		})
+	})
+
+	Context("Poll", func() {
+		It("should emit only new Tweets, oldest first", func() {
+			ids := []string{"1000", "900", "800"}
+			var requests [][2]string
+			w := NewTimelineWalker(fakeTimeline(&ids, &requests), TimelineParams{SinceID: "900"})
+
+			errDone := errors.New("done")
+			var tweets []Tweet
+			err := w.Poll(context.Background(), time.Millisecond, func(t Tweet) error {
+				tweets = append(tweets, t)
+				if t.IDStr == "1000" {
+					ids = append([]string{"1300", "1200", "1100"}, ids...)
+				}
+				if t.IDStr == "1300" {
+					return errDone
+				}
+				return nil
+			})
+			Ω(err).Should(Equal(errDone))
+			Ω(tweetIDs(tweets)).Should(Equal([]string{"1000", "1100", "1200", "1300"}))
+			Ω(w.SinceID()).Should(Equal("1200"))
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// TrendsPlaceParams represents the query parameters for a /trends/place.json
+// request. ID is the Yahoo! Where On Earth ID of the location, or 1 for
+// worldwide trends.
+type TrendsPlaceParams struct {
+	ID              int64
+	ExcludeHashtags bool
+}
+
+// TrendsPlace calls the Twitter /trends/place.json endpoint.
+func (c *Client) TrendsPlace(ctx context.Context, params TrendsPlaceParams) (*TrendsResponse, error) {
+	values := url.Values{}
+	values.Set("id", strconv.FormatInt(params.ID, 10))
+	if params.ExcludeHashtags {
+		values.Set("exclude", "hashtags")
+	}
+	resp, err := c.do(ctx, "GET", c.apiURL("/1.1/trends/place.json"), values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var trends []Trends
+	err = json.NewDecoder(resp.Body).Decode(&trends)
+	if err != nil {
+		return nil, err
+	}
+	res := &TrendsResponse{
+		RateLimit: getRateLimit(resp.Header),
+	}
+	if len(trends) > 0 {
+		res.Trends = trends[0]
+	}
+	return res, nil
+}
+
+// TrendsAvailable calls the Twitter /trends/available.json endpoint.
+func (c *Client) TrendsAvailable(ctx context.Context) (*TrendLocationsResponse, error) {
+	return c.handleTrendLocationsResponse(ctx, c.apiURL("/1.1/trends/available.json"), url.Values{})
+}
+
+// TrendsClosest calls the Twitter /trends/closest.json endpoint.
+func (c *Client) TrendsClosest(ctx context.Context, loc Location) (*TrendLocationsResponse, error) {
+	values := url.Values{}
+	setGeoLocation(values, &loc)
+	return c.handleTrendLocationsResponse(ctx, c.apiURL("/1.1/trends/closest.json"), values)
+}
+
+func (c *Client) handleTrendLocationsResponse(ctx context.Context, urlStr string, values url.Values) (*TrendLocationsResponse, error) {
+	resp, err := c.do(ctx, "GET", urlStr, values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var locs []TrendLocation
+	err = json.NewDecoder(resp.Body).Decode(&locs)
+	if err != nil {
+		return nil, err
+	}
+	return &TrendLocationsResponse{
+		Locations: locs,
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package twitter
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/garyburd/go-oauth/oauth"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Trends", func() {
+	Context("TrendsPlace", func() {
+		It("should return error when http request fails", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: 400,
+						Body:       ioutil.NopCloser(strings.NewReader(`{"errors": [{"code": 400, "message": "oops"}]}`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			_, err := client.TrendsPlace(context.Background(), TrendsPlaceParams{ID: 1})
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("oops"))
+		})
+
+		It("should return the trends for the place", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/trends/place.json"))
+					Ω(req.FormValue("id")).Should(Equal("4118"))
+					Ω(req.FormValue("exclude")).Should(Equal("hashtags"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`[{"as_of":"2017-01-01T00:00:00Z","locations":[{"name":"Toronto","woeid":4118}],"trends":[{"name":"Raptors","tweet_volume":1200},{"name":"Leafs","tweet_volume":null}]}]`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.TrendsPlace(context.Background(), TrendsPlaceParams{ID: 4118, ExcludeHashtags: true})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Trends.Locations[0].WOEID).Should(Equal(int64(4118)))
+			Ω(res.Trends.Trends).Should(HaveLen(2))
+			Ω(res.Trends.Trends[0].TweetVolume).Should(Equal(1200))
+			Ω(res.Trends.Trends[1].TweetVolume).Should(Equal(0))
+		})
+	})
+
+	Context("TrendsClosest", func() {
+		It("should return the closest locations", func() {
+			hm := HTTPMock{
+				DoFn: func(req *http.Request) (*http.Response, error) {
+					Ω(req.URL.Path).Should(Equal("/1.1/trends/closest.json"))
+					Ω(req.FormValue("lat")).Should(Equal("43.65"))
+					Ω(req.FormValue("long")).Should(Equal("-79.38"))
+					return &http.Response{
+						StatusCode: 200,
+						Body:       ioutil.NopCloser(strings.NewReader(`[{"name":"Toronto","woeid":4118,"parentid":23424775,"countryCode":"CA","placeType":{"code":7,"name":"Town"}}]`)),
+					}, nil
+				},
+			}
+			client := Client{httpClient: &hm, oauthClient: &oauth.Client{}, accessCreds: &oauth.Credentials{}}
+			res, err := client.TrendsClosest(context.Background(), Location{Lat: 43.65, Long: -79.38})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(res.Locations).Should(HaveLen(1))
+			Ω(res.Locations[0].CountryCode).Should(Equal("CA"))
+			Ω(res.Locations[0].PlaceType.Name).Should(Equal("Town"))
+		})
+	})
+})
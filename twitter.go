@@ -25,6 +25,45 @@ type Location struct {
 	Long float64
 }
 
+// TrendsResponse represents a response from Twitter containing the trending
+// topics for a location.
+type TrendsResponse struct {
+	Trends    Trends
+	RateLimit RateLimit
+}
+
+// TrendLocationsResponse represents a response from Twitter containing
+// multiple TrendLocations.
+type TrendLocationsResponse struct {
+	Locations []TrendLocation
+	RateLimit RateLimit
+}
+
+// PlaceResponse represents a response from Twitter containing a single Place.
+type PlaceResponse struct {
+	Place     Place
+	RateLimit RateLimit
+}
+
+// PlacesResponse represents a response from Twitter containing multiple
+// Places.
+type PlacesResponse struct {
+	Places    []Place
+	RateLimit RateLimit
+}
+
+// SettingsResponse represents a response from Twitter containing account
+// settings.
+type SettingsResponse struct {
+	Settings  Settings
+	RateLimit RateLimit
+}
+
+// EmptyResponse represents a response from Twitter with no content.
+type EmptyResponse struct {
+	RateLimit RateLimit
+}
+
 // TweetResponse represents a response from Twitter containing a single Tweet.
 type TweetResponse struct {
 	Tweet     Tweet
@@ -91,6 +130,32 @@ type UsersResponse struct {
 	Users     []User
 }
 
+// CursoredUsersResponse represents a response from Twitter with a paginated
+// list of user objects.
+type CursoredUsersResponse struct {
+	RateLimit RateLimit
+	Users     CursoredUsers
+}
+
+// ListResponse represents a response from Twitter with a list object.
+type ListResponse struct {
+	RateLimit RateLimit
+	List      List
+}
+
+// ListsResponse represents a response from Twitter with multiple list objects.
+type ListsResponse struct {
+	RateLimit RateLimit
+	Lists     []List
+}
+
+// CursoredListsResponse represents a response from Twitter with a paginated
+// list of list objects.
+type CursoredListsResponse struct {
+	RateLimit RateLimit
+	Lists     CursoredLists
+}
+
 // FriendshipResponse represents a response from Twitter with two objects describing the relationship between two users
 type FriendshipResponse struct {
 	RateLimit  RateLimit
@@ -175,12 +240,34 @@ func (c *Client) handleIDsResponse(ctx context.Context, method, urlStr string, v
 	}, nil
 }
 
-func (c *Client) handleUserResponse(ctx context.Context, method, urlStr string, values url.Values) (*UserResponse, error) {
+// handleIDListResponse handles endpoints that return a bare array of IDs rather
+// than the cursored IDs model.
+func (c *Client) handleIDListResponse(ctx context.Context, method, urlStr string, values url.Values) (*IDsResponse, error) {
 	resp, err := c.do(ctx, method, urlStr, values)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var ids []string
+	err = json.NewDecoder(resp.Body).Decode(&ids)
+	if err != nil {
+		return nil, err
+	}
+	return &IDsResponse{
+		IDs:       IDs{IDs: ids},
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
+
+func (c *Client) handleUserResponse(ctx context.Context, method, urlStr string, values url.Values) (*UserResponse, error) {
+	resp, err := c.do(ctx, method, urlStr, values)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	if err = checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -197,10 +284,10 @@ func (c *Client) handleUserResponse(ctx context.Context, method, urlStr string,
 
 func (c *Client) handleUsersResponse(ctx context.Context, method, urlStr string, values url.Values) (*UsersResponse, error) {
 	resp, err := c.do(ctx, method, urlStr, values)
-	defer resp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	if err = checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -215,12 +302,92 @@ func (c *Client) handleUsersResponse(ctx context.Context, method, urlStr string,
 	}, nil
 }
 
-func (c *Client) handleFriendshipResponse(ctx context.Context, method, urlStr string, values url.Values) (*FriendshipResponse, error) {
+func (c *Client) handleCursoredUsersResponse(ctx context.Context, method, urlStr string, values url.Values) (*CursoredUsersResponse, error) {
 	resp, err := c.do(ctx, method, urlStr, values)
+	if err != nil {
+		return nil, err
+	}
 	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var users CursoredUsers
+	err = json.NewDecoder(resp.Body).Decode(&users)
 	if err != nil {
 		return nil, err
 	}
+	return &CursoredUsersResponse{
+		Users:     users,
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
+
+func (c *Client) handleListResponse(ctx context.Context, method, urlStr string, values url.Values) (*ListResponse, error) {
+	resp, err := c.do(ctx, method, urlStr, values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var list List
+	err = json.NewDecoder(resp.Body).Decode(&list)
+	if err != nil {
+		return nil, err
+	}
+	return &ListResponse{
+		List:      list,
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
+
+func (c *Client) handleListsResponse(ctx context.Context, method, urlStr string, values url.Values) (*ListsResponse, error) {
+	resp, err := c.do(ctx, method, urlStr, values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var lists []List
+	err = json.NewDecoder(resp.Body).Decode(&lists)
+	if err != nil {
+		return nil, err
+	}
+	return &ListsResponse{
+		Lists:     lists,
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
+
+func (c *Client) handleCursoredListsResponse(ctx context.Context, method, urlStr string, values url.Values) (*CursoredListsResponse, error) {
+	resp, err := c.do(ctx, method, urlStr, values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err = checkResponse(resp); err != nil {
+		return nil, err
+	}
+	var lists CursoredLists
+	err = json.NewDecoder(resp.Body).Decode(&lists)
+	if err != nil {
+		return nil, err
+	}
+	return &CursoredListsResponse{
+		Lists:     lists,
+		RateLimit: getRateLimit(resp.Header),
+	}, nil
+}
+
+func (c *Client) handleFriendshipResponse(ctx context.Context, method, urlStr string, values url.Values) (*FriendshipResponse, error) {
+	resp, err := c.do(ctx, method, urlStr, values)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
 	if err = checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -237,10 +404,10 @@ func (c *Client) handleFriendshipResponse(ctx context.Context, method, urlStr st
 
 func (c *Client) handleFriendshipsResponse(ctx context.Context, method, urlStr string, values url.Values) (*FriendshipLookupResponse, error) {
 	resp, err := c.do(ctx, method, urlStr, values)
-	defer resp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	if err = checkResponse(resp); err != nil {
 		return nil, err
 	}
@@ -257,10 +424,10 @@ func (c *Client) handleFriendshipsResponse(ctx context.Context, method, urlStr s
 
 func (c *Client) handleMediaUpload(ctx context.Context, method, urlStr string, query mediaUploadQueryResponse) (*MediaUploadResponse, error) {
 	resp, err := c.execute(ctx, method, urlStr, query.ContentType, query.Body, nil)
-	defer resp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	if err = checkResponse(resp); err != nil {
 		return nil, err
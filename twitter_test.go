@@ -1,6 +1,10 @@
 package twitter_test
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+
 	. "github.com/crowdriff/twitter"
 
 	. "github.com/onsi/ginkgo"
@@ -24,4 +28,22 @@ var _ = Describe("Twitter", func() {
 		})
 	})
 
+	Context("WithBaseURLs", func() {
+		It("should send requests to the provided base URL", func() {
+			var path string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.Write([]byte(`[]`))
+			}))
+			defer srv.Close()
+
+			client := NewClient(ConsumerCredentials{}, AccessCredentials{}, nil).WithBaseURLs(BaseURLs{
+				API: srv.URL + "/",
+			})
+			_, err := client.UserTimeline(context.Background(), UserTimelineParams{})
+			Ω(err).ShouldNot(HaveOccurred())
+			Ω(path).Should(Equal("/1.1/statuses/user_timeline.json"))
+		})
+	})
+
 })
@@ -18,7 +18,7 @@ type SearchUsersParams struct {
 // SearchUsers calls Twitter endpoint /users/search.json
 func (c *Client) SearchUsers(ctx context.Context, params SearchUsersParams) (*UsersResponse, error) {
 	values := searchUsersToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/users/search.json"
+	urlStr := c.apiURL("/1.1/users/search.json")
 	return c.handleUsersResponse(ctx, "GET", urlStr, values)
 }
 
@@ -47,7 +47,7 @@ type ShowUserParams struct {
 // ShowUser calls Twitter endpoint /users/show.json
 func (c *Client) ShowUser(ctx context.Context, params ShowUserParams) (*UserResponse, error) {
 	values := showUserToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/users/show.json"
+	urlStr := c.apiURL("/1.1/users/show.json")
 	return c.handleUserResponse(ctx, "GET", urlStr, values)
 }
 
@@ -75,7 +75,7 @@ type LookupUsersParams struct {
 // LookupUsers calls Twitter endpoint /users/lookup.json
 func (c *Client) LookupUsers(ctx context.Context, params LookupUsersParams) (*UsersResponse, error) {
 	values := lookupUsersToQuery(params)
-	urlStr := "https://api.twitter.com/1.1/users/lookup.json"
+	urlStr := c.apiURL("/1.1/users/lookup.json")
 	return c.handleUsersResponse(ctx, "POST", urlStr, values)
 }
 